@@ -4,15 +4,76 @@
 
 package sysinfo
 
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
 // BIOS information.
 type BIOS struct {
-	Vendor  string `json:"vendor,omitempty"`
-	Version string `json:"version,omitempty"`
-	Date    string `json:"date,omitempty"`
+	Vendor          string   `json:"vendor,omitempty"`
+	Version         string   `json:"version,omitempty"`
+	Date            string   `json:"date,omitempty"`
+	Release         string   `json:"release,omitempty"`   // system BIOS major.minor release
+	ECRelease       string   `json:"ecrelease,omitempty"` // embedded controller firmware major.minor release
+	ROMSize         uint     `json:"romsize,omitempty"`   // BIOS ROM size in KB
+	Characteristics []string `json:"characteristics,omitempty"`
+	Mode            string   `json:"mode,omitempty"` // UEFI or Legacy, as the system was booted
+}
+
+func release(major, minor uint8) string {
+	if major == 0xff && minor == 0xff {
+		return ""
+	}
+
+	return fmt.Sprintf("%d.%d", major, minor)
+}
+
+// EFI runtime services are exported only when booted through UEFI. Their absence means legacy boot only if firmware
+// is exported at all, it isn't in containers without sysfs, or in captures of other sections.
+func (si *SysInfo) getBIOSMode(p *probe) {
+	if _, err := p.stat("/sys/firmware"); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			p.failPath(err)
+		}
+		return
+	}
+
+	if _, err := p.stat("/sys/firmware/efi"); err == nil {
+		si.BIOS.Mode = "UEFI"
+	} else if errors.Is(err, fs.ErrNotExist) {
+		si.BIOS.Mode = "Legacy"
+	} else {
+		p.failPath(err)
+	}
 }
 
-func (si *SysInfo) getBIOSInfo() {
-	si.BIOS.Vendor = slurpFile("/sys/class/dmi/id/bios_vendor")
-	si.BIOS.Version = slurpFile("/sys/class/dmi/id/bios_version")
-	si.BIOS.Date = slurpFile("/sys/class/dmi/id/bios_date")
+func (si *SysInfo) getBIOSInfo(p *probe) {
+	si.BIOS.Vendor = p.slurpFile("/sys/class/dmi/id/bios_vendor")
+	si.BIOS.Version = p.slurpFile("/sys/class/dmi/id/bios_version")
+	si.BIOS.Date = p.slurpFile("/sys/class/dmi/id/bios_date")
+
+	si.getBIOSMode(p)
+
+	t := getSMBIOS(p)
+	if t == nil {
+		return
+	}
+
+	for _, s := range t.Type(smbios.TypeBIOS) {
+		b, err := s.BIOS()
+		if err != nil {
+			p.failSMBIOS(err)
+			continue
+		}
+
+		si.BIOS.Release = release(b.BIOSMajorRelease, b.BIOSMinorRelease)
+		si.BIOS.ECRelease = release(b.FirmwareMajorRelease, b.FirmwareMinorRelease)
+		si.BIOS.ROMSize = uint(b.ROMSize >> 10)
+		si.BIOS.Characteristics = append(b.Characteristics.Strings(), b.CharacteristicsExt.Strings()...)
+		break
+	}
 }
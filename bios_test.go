@@ -0,0 +1,34 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestBIOSMode(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"uefi", map[string]string{"sys/firmware/efi/fw_platform_size": "64"}, "UEFI"},
+		{"legacy", map[string]string{"sys/firmware/acpi/pm_profile": "1"}, "Legacy"},
+		{"no sysfs", map[string]string{"etc/hostname": "test"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			si := sysinfo.SysInfo{Root: fixture(t, tt.files)}
+			_ = si.Collect(sysinfo.SectionBIOS)
+
+			if si.BIOS.Mode != tt.want {
+				t.Errorf("BIOS.Mode = %q, want %q", si.BIOS.Mode, tt.want)
+			}
+		})
+	}
+}
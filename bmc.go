@@ -0,0 +1,79 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// BMC (baseboard management controller) information, as found in SMBIOS and the running kernel.
+type BMC struct {
+	Present         *bool    `json:"present,omitempty"`     // nil if it couldn't be determined
+	Interface       string   `json:"interface,omitempty"`   // system interface type, like KCS or SSIF
+	SpecVersion     string   `json:"specversion,omitempty"` // IPMI specification revision
+	I2CAddress      string   `json:"i2caddress,omitempty"`
+	BaseAddress     string   `json:"baseaddress,omitempty"`
+	RegisterSpacing string   `json:"registerspacing,omitempty"`
+	Interrupt       uint     `json:"interrupt,omitempty"`
+	Devices         []string `json:"devices,omitempty"` // IPMI device files, like /dev/ipmi0
+	Drivers         []string `json:"drivers,omitempty"` // loaded IPMI system interface drivers
+}
+
+func (si *SysInfo) getBMCInfo(p *probe) {
+	si.BMC = BMC{}
+	present := false
+
+	if t := getSMBIOS(p); t != nil {
+		for _, s := range t.Type(smbios.TypeIPMIDevice) {
+			d, err := s.IPMIDevice()
+			if err != nil {
+				p.failSMBIOS(err)
+				continue
+			}
+
+			present = true
+			si.BMC.Interface = d.InterfaceType.String()
+			si.BMC.SpecVersion = fmt.Sprintf("%d.%d", d.SpecRevision.Major, d.SpecRevision.Minor)
+			si.BMC.I2CAddress = fmt.Sprintf("0x%02x", d.I2CAddress>>1)
+			si.BMC.BaseAddress = d.Address()
+			if d.InterfaceType != smbios.IPMIInterfaceSSIF {
+				si.BMC.RegisterSpacing = d.RegisterSpacing.String()
+			}
+			si.BMC.Interrupt = uint(d.Interrupt)
+			break
+		}
+	}
+
+	devices, err := p.readDir("/dev")
+	if err != nil {
+		p.failPath(err)
+	}
+	for _, dev := range devices {
+		if strings.HasPrefix(dev.Name(), "ipmi") {
+			si.BMC.Devices = append(si.BMC.Devices, path.Join("/dev", dev.Name()))
+		}
+	}
+
+	// Built-in drivers are listed in /sys/module too, as long as they have parameters, which both have.
+	for _, driver := range []string{"ipmi_si", "ipmi_ssif"} {
+		if _, err := p.stat(path.Join("/sys/module", driver)); err == nil {
+			si.BMC.Drivers = append(si.BMC.Drivers, driver)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			p.failPath(err)
+		}
+	}
+
+	// Device files exist only when the kernel found the BMC through one of the drivers.
+	present = present || len(si.BMC.Devices) > 0
+	if present || p.conclusive() {
+		si.BMC.Present = &present
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestBMC(t *testing.T) {
+	// KCS interface at I/O port 0xca2.
+	ipmi := structure(38, 0x2600, formatted(0x12, map[int]any{
+		0x04: uint8(1), 0x05: uint8(0x20), 0x06: uint8(0x20), 0x07: uint8(0xff), 0x08: uint64(0xca3),
+	}))
+
+	present, absent := true, false
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  sysinfo.BMC
+	}{
+		{
+			name:  "device",
+			files: map[string]string{"dev/ipmi0": ""},
+			want:  sysinfo.BMC{Present: &present, Devices: []string{"/dev/ipmi0"}},
+		},
+		{
+			// Driver loaded, but it didn't find a BMC.
+			name:  "driver",
+			files: map[string]string{"dev/null": "", "sys/module/ipmi_si/parameters/type": "kcs"},
+			want:  sysinfo.BMC{Present: &absent, Drivers: []string{"ipmi_si"}},
+		},
+		{
+			name: "smbios",
+			files: map[string]string{
+				"sys/firmware/dmi/tables/DMI":        ipmi + endOfTable,
+				"dev/ipmi0":                          "",
+				"sys/module/ipmi_si/parameters/type": "kcs",
+			},
+			want: sysinfo.BMC{
+				Present:         &present,
+				Interface:       "KCS (Keyboard Controller Style)",
+				SpecVersion:     "2.0",
+				I2CAddress:      "0x10",
+				BaseAddress:     "0x0000000000000CA2 (I/O)",
+				RegisterSpacing: "Successive byte boundaries",
+				Devices:         []string{"/dev/ipmi0"},
+				Drivers:         []string{"ipmi_si"},
+			},
+		},
+		{
+			// SMBIOS table can't be read, the BMC may well be there.
+			name:  "unknown",
+			files: map[string]string{"dev/null": "", "sys/firmware/dmi/tables/DMI": "\x26\x02\x00\x00"},
+			want:  sysinfo.BMC{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			si := sysinfo.SysInfo{Root: fixture(t, tt.files)}
+			_ = si.Collect(sysinfo.SectionBMC)
+
+			if !reflect.DeepEqual(si.BMC, tt.want) {
+				t.Errorf("BMC = %+v, want %+v", si.BMC, tt.want)
+			}
+		})
+	}
+}
@@ -13,10 +13,10 @@ type Board struct {
 	AssetTag string `json:"assettag,omitempty"`
 }
 
-func (si *SysInfo) getBoardInfo() {
-	si.Board.Name = slurpFile("/sys/class/dmi/id/board_name")
-	si.Board.Vendor = slurpFile("/sys/class/dmi/id/board_vendor")
-	si.Board.Version = slurpFile("/sys/class/dmi/id/board_version")
-	si.Board.Serial = slurpFile("/sys/class/dmi/id/board_serial")
-	si.Board.AssetTag = slurpFile("/sys/class/dmi/id/board_asset_tag")
+func (si *SysInfo) getBoardInfo(p *probe) {
+	si.Board.Name = p.slurpFile("/sys/class/dmi/id/board_name")
+	si.Board.Vendor = p.slurpFile("/sys/class/dmi/id/board_vendor")
+	si.Board.Version = p.slurpFile("/sys/class/dmi/id/board_version")
+	si.Board.Serial = p.slurpFile("/sys/class/dmi/id/board_serial")
+	si.Board.AssetTag = p.slurpFile("/sys/class/dmi/id/board_asset_tag")
 }
@@ -0,0 +1,250 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Capture holds everything read from the system while gathering information: files, directory listings, symbolic
+// links, and results of syscalls (stored as files under /.sysinfo).
+//
+// To make a capture, set SysInfo.Capture to an empty Capture before gathering information, and save it with WriteTo.
+// To replay it, load it with ReadCapture and set SysInfo.Replay, so information is gathered from the capture instead
+// of the running system. That makes it easy to reproduce problems seen on other machines, and turn them into tests.
+type Capture struct {
+	mu    sync.Mutex
+	nodes map[string]*captured
+}
+
+type captured struct {
+	mode fs.FileMode
+	data []byte
+	link string
+}
+
+// Add file system node, merging with what's already known about it. Safe to call on nil Capture.
+func (c *Capture) add(name string, mode fs.FileMode, data []byte, link string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Relative paths (of the SMBIOS dump) are kept relative to the root of the capture, the way they are saved.
+	name = path.Clean("/" + name)
+	if c.nodes == nil {
+		c.nodes = make(map[string]*captured)
+	}
+
+	n, ok := c.nodes[name]
+	if !ok {
+		n = &captured{mode: mode}
+		c.nodes[name] = n
+	}
+
+	if data != nil {
+		n.data = data
+	}
+	if link != "" {
+		n.mode = fs.ModeSymlink | 0777
+		n.link = link
+	}
+}
+
+func (c *Capture) lookup(op, name string) (*captured, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n, ok := c.nodes[path.Clean("/"+name)]; ok {
+		return n, nil
+	}
+
+	return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
+
+func (c *Capture) readFile(name string) ([]byte, error) {
+	n, err := c.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: syscall.EISDIR}
+	}
+
+	return n.data, nil
+}
+
+func (c *Capture) readDir(name string) ([]fs.DirEntry, error) {
+	n, err := c.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: syscall.ENOTDIR}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entries []fs.DirEntry
+	for p, n := range c.nodes {
+		if p != name && path.Dir(p) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(&capturedInfo{path.Base(p), n}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+func (c *Capture) readlink(name string) (string, error) {
+	n, err := c.lookup("readlink", name)
+	if err != nil {
+		return "", err
+	}
+
+	if n.mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: syscall.EINVAL}
+	}
+
+	return n.link, nil
+}
+
+func (c *Capture) stat(op, name string) (fs.FileInfo, error) {
+	n, err := c.lookup(op, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &capturedInfo{path.Base(name), n}, nil
+}
+
+// WriteTo saves the capture as a gzip compressed tar archive.
+func (c *Capture) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.nodes))
+	for name := range c.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countingWriter{w: w}
+	zw := gzip.NewWriter(cw)
+	tw := tar.NewWriter(zw)
+
+	for _, name := range names {
+		n := c.nodes[name]
+		hdr := &tar.Header{
+			Name:    strings.TrimPrefix(name, "/"),
+			Mode:    int64(n.mode.Perm()),
+			ModTime: time.Unix(0, 0),
+		}
+
+		switch {
+		case n.mode.IsDir():
+			hdr.Typeflag = tar.TypeDir
+		case n.mode&fs.ModeSymlink != 0:
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = n.link
+		default:
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = int64(len(n.data))
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return cw.n, err
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err := tw.Write(n.data); err != nil {
+				return cw.n, err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return cw.n, err
+	}
+	err := zw.Close()
+
+	return cw.n, err
+}
+
+// ReadCapture loads a capture saved with WriteTo.
+func ReadCapture(r io.Reader) (*Capture, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	c := &Capture{nodes: make(map[string]*captured)}
+
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		n := &captured{mode: fs.FileMode(hdr.Mode).Perm()}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			n.mode |= fs.ModeDir
+		case tar.TypeSymlink:
+			n.mode |= fs.ModeSymlink
+			n.link = hdr.Linkname
+		default:
+			if n.data, err = io.ReadAll(tr); err != nil {
+				return nil, err
+			}
+		}
+
+		c.nodes[path.Clean("/"+hdr.Name)] = n
+	}
+
+	return c, nil
+}
+
+// capturedInfo implements fs.FileInfo.
+type capturedInfo struct {
+	name string
+	n    *captured
+}
+
+func (fi *capturedInfo) Name() string       { return fi.name }
+func (fi *capturedInfo) Size() int64        { return int64(len(fi.n.data)) }
+func (fi *capturedInfo) Mode() fs.FileMode  { return fi.n.mode }
+func (fi *capturedInfo) ModTime() time.Time { return time.Time{} }
+func (fi *capturedInfo) IsDir() bool        { return fi.n.mode.IsDir() }
+func (fi *capturedInfo) Sys() any           { return nil }
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,39 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestCaptureReplay(t *testing.T) {
+	live := sysinfo.SysInfo{
+		ReadOnly: true,
+		Capture:  &sysinfo.Capture{},
+	}
+	live.GetSysInfo()
+
+	var buf bytes.Buffer
+	if _, err := live.Capture.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	capture, err := sysinfo.ReadCapture(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := sysinfo.SysInfo{
+		Replay: capture,
+	}
+	replayed.GetSysInfo()
+
+	if changes := sysinfo.Diff(&live, &replayed); len(changes) != 0 {
+		t.Errorf("replayed capture differs from live system: %v", changes)
+	}
+}
@@ -4,23 +4,120 @@
 
 package sysinfo
 
-import "strconv"
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// ChassisType is the SMBIOS chassis type, like 3 (Desktop) or 23 (Rack Mount Chassis).
+type ChassisType uint
+
+// String returns name of the chassis type.
+func (t ChassisType) String() string {
+	return smbios.ChassisType(t).String()
+}
+
+type chassisTypeJSON struct {
+	Code uint   `json:"code"`
+	Name string `json:"name"`
+}
+
+// MarshalJSON encodes chassis type as an object with both code and name.
+func (t ChassisType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(chassisTypeJSON{uint(t), t.String()})
+}
+
+// UnmarshalJSON decodes chassis type from an object with code and name, or from a plain number (older outputs).
+func (t *ChassisType) UnmarshalJSON(data []byte) error {
+	var code uint
+	if err := json.Unmarshal(data, &code); err == nil {
+		*t = ChassisType(code)
+		return nil
+	}
+
+	var ct chassisTypeJSON
+	if err := json.Unmarshal(data, &ct); err != nil {
+		return err
+	}
+
+	*t = ChassisType(ct.Code)
+	return nil
+}
+
+// ChassisElement is a type of element (board or device) the chassis can contain, with the allowed count.
+type ChassisElement struct {
+	Type string `json:"type,omitempty"`
+	Min  uint   `json:"min"`
+	Max  uint   `json:"max"`
+}
 
 // Chassis information.
 type Chassis struct {
-	Type     uint   `json:"type,omitempty"`
-	Vendor   string `json:"vendor,omitempty"`
-	Version  string `json:"version,omitempty"`
-	Serial   string `json:"serial,omitempty"`
-	AssetTag string `json:"assettag,omitempty"`
+	Type             ChassisType      `json:"type,omitempty"`
+	Vendor           string           `json:"vendor,omitempty"`
+	Version          string           `json:"version,omitempty"`
+	Serial           string           `json:"serial,omitempty"`
+	AssetTag         string           `json:"assettag,omitempty"`
+	BootupState      string           `json:"bootupstate,omitempty"`
+	PowerSupplyState string           `json:"powersupplystate,omitempty"`
+	ThermalState     string           `json:"thermalstate,omitempty"`
+	SecurityStatus   string           `json:"securitystatus,omitempty"`
+	Height           uint             `json:"height,omitempty"`     // in rack units (U)
+	PowerCords       uint             `json:"powercords,omitempty"` // number of power cords
+	Elements         []ChassisElement `json:"elements,omitempty"`   // contained elements
 }
 
-func (si *SysInfo) getChassisInfo() {
-	if chtype, err := strconv.ParseUint(slurpFile("/sys/class/dmi/id/chassis_type"), 10, 64); err == nil {
-		si.Chassis.Type = uint(chtype)
+func (si *SysInfo) getChassisInfo(p *probe) {
+	if chtype := p.slurpFile("/sys/class/dmi/id/chassis_type"); chtype != "" {
+		if t, err := strconv.ParseUint(chtype, 10, 64); err == nil {
+			si.Chassis.Type = ChassisType(t)
+		} else {
+			p.failParse("/sys/class/dmi/id/chassis_type", err)
+		}
+	}
+	si.Chassis.Vendor = p.slurpFile("/sys/class/dmi/id/chassis_vendor")
+	si.Chassis.Version = p.slurpFile("/sys/class/dmi/id/chassis_version")
+	si.Chassis.Serial = p.slurpFile("/sys/class/dmi/id/chassis_serial")
+	si.Chassis.AssetTag = p.slurpFile("/sys/class/dmi/id/chassis_asset_tag")
+
+	// States, height, power cords and elements are extras, the section is complete without them, even if the table
+	// can't be read.
+	t := p.dmi.read(p)
+	if t == nil {
+		return
+	}
+
+	for _, s := range t.Type(smbios.TypeChassis) {
+		c, err := s.Chassis()
+		if err != nil {
+			p.failSMBIOS(err)
+			if c == nil {
+				continue
+			}
+		}
+
+		// States were added in SMBIOS 2.1, older tables leave them zero.
+		if c.BootupState != 0 {
+			si.Chassis.BootupState = c.BootupState.String()
+		}
+		if c.PowerSupplyState != 0 {
+			si.Chassis.PowerSupplyState = c.PowerSupplyState.String()
+		}
+		if c.ThermalState != 0 {
+			si.Chassis.ThermalState = c.ThermalState.String()
+		}
+		if c.SecurityStatus != 0 {
+			si.Chassis.SecurityStatus = c.SecurityStatus.String()
+		}
+		si.Chassis.Height = uint(c.Height)
+		si.Chassis.PowerCords = uint(c.PowerCords)
+		si.Chassis.Elements = nil
+		for _, e := range c.Elements {
+			si.Chassis.Elements = append(si.Chassis.Elements, ChassisElement{e.String(), uint(e.Minimum),
+				uint(e.Maximum)})
+		}
+		break
 	}
-	si.Chassis.Vendor = slurpFile("/sys/class/dmi/id/chassis_vendor")
-	si.Chassis.Version = slurpFile("/sys/class/dmi/id/chassis_version")
-	si.Chassis.Serial = slurpFile("/sys/class/dmi/id/chassis_serial")
-	si.Chassis.AssetTag = slurpFile("/sys/class/dmi/id/chassis_asset_tag")
 }
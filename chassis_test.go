@@ -0,0 +1,30 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChassisTypeJSON(t *testing.T) {
+	data, err := json.Marshal(Chassis{Type: 23})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":{"code":23,"name":"Rack Mount Chassis"}}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	for _, in := range []string{string(data), `{"type":23}`} {
+		var c Chassis
+		if err := json.Unmarshal([]byte(in), &c); err != nil {
+			t.Fatal(err)
+		}
+		if c.Type != 23 {
+			t.Errorf("json.Unmarshal(%s) type = %d, want 23", in, c.Type)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func load(path string) *sysinfo.SysInfo {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var si sysinfo.SysInfo
+	if err := json.Unmarshal(data, &si); err != nil {
+		log.Fatalf("%s: %v", path, err)
+	}
+
+	return &si
+}
+
+// Compare two saved JSON outputs, print changes as JSON.
+func diff(args []string) {
+	if len(args) != 2 {
+		log.Fatal("usage: sysinfo diff OLD.json NEW.json")
+	}
+
+	changes := sysinfo.Diff(load(args[0]), load(args[1]))
+	if changes == nil {
+		changes = []sysinfo.Change{}
+	}
+
+	data, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(data))
+}
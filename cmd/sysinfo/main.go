@@ -5,30 +5,118 @@
 // sysinfo is a very simple utility demonstrating sysinfo library capabilities. Start it to get pretty formatted JSON
 // output of all the info that sysinfo library provides. Due to its simplicity, the source code of the utility also
 // doubles down as an example of how to use the library.
+//
+// Run "sysinfo diff OLD.json NEW.json" to get the list of changes between two saved outputs. Run "sysinfo -capture
+// FILE" to save everything the library reads into an archive that can be attached to a bug report, and replayed with
+// "sysinfo -replay FILE". Run "sysinfo -smbios FILE" to decode SMBIOS table dumped on another machine, like with
+// "dmidecode --dump-bin FILE" (information that is not in SMBIOS, like OS or network, is still of this machine).
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
-	"os/user"
+	"os"
+	"strings"
 
 	"github.com/zcalusic/sysinfo"
 )
 
+var (
+	readOnly = flag.Bool("readonly", false, "never modify the system (don't repair machine IDs)")
+	sections = flag.String("sections", "", "comma separated list of sections to gather (default all)")
+	timeout  = flag.Duration("timeout", 0, "give up on sections not gathered in time (default no timeout)")
+	capture  = flag.String("capture", "", "save everything read from the system to `file`, for bug reports")
+	replay   = flag.String("replay", "", "gather information from capture `file`, instead of the running system")
+	dump     = flag.String("smbios", "", "decode SMBIOS dump `file` (like dmidecode --dump-bin output)")
+)
+
 func main() {
-	current, err := user.Current()
-	if err != nil {
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "":
+	case "diff":
+		diff(flag.Args()[1:])
+		return
+	default:
+		log.Fatalf("unknown command %q", flag.Arg(0))
+	}
+
+	si := sysinfo.SysInfo{
+		ReadOnly: *readOnly,
+	}
+
+	if *replay != "" {
+		f, err := os.Open(*replay)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if si.Replay, err = sysinfo.ReadCapture(f); err != nil {
+			log.Fatalf("%s: %v", *replay, err)
+		}
+		f.Close()
+	}
+
+	// Path is kept as given, replayed dump is found in the capture under the same name, no matter the directory.
+	si.SMBIOS = *dump
+
+	if *capture != "" {
+		si.Capture = &sysinfo.Capture{}
+	}
+
+	var selected []sysinfo.Section
+	if *sections != "" {
+		for _, s := range strings.Split(*sections, ",") {
+			selected = append(selected, sysinfo.Section(strings.TrimSpace(s)))
+		}
+	}
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	err := si.GetSysInfoContext(ctx, selected...)
+	if errors.Is(err, sysinfo.ErrUnknownSection) {
 		log.Fatal(err)
 	}
 
-	if current.Uid != "0" {
-		log.Fatal("requires superuser privilege")
+	// Failures to gather some of the information are not fatal, affected sections are marked in the output. Missing
+	// files are normal, not every system has every file, but the SMBIOS dump asked for must be there, and decode.
+	var errs sysinfo.Errors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			switch {
+			case *dump != "" && e.Collector == "smbios":
+				log.Fatal(e)
+			case !errors.Is(e, fs.ErrNotExist):
+				log.Print(e)
+			}
+		}
 	}
 
-	var si sysinfo.SysInfo
+	if si.Capture != nil {
+		f, err := os.Create(*capture)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	si.GetSysInfo()
+		if _, err := si.Capture.WriteTo(f); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	data, err := json.MarshalIndent(&si, "", "  ")
 	if err != nil {
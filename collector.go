@@ -0,0 +1,127 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Collector gathers a custom section of system information, which is stored in SysInfo.Extensions, under its name.
+type Collector interface {
+	// Name of the section, must be unique.
+	Name() string
+
+	// Collect gathers the section, the returned value must be serializable to JSON. It runs after the sections it
+	// depends on have been gathered (they are gathered even when only the custom section is asked for), so it can use
+	// them, but must not modify si.
+	Collect(ctx context.Context, si *SysInfo) (any, error)
+}
+
+// Dependent is implemented by collectors that declare which sections they depend on, built-in ones, or custom ones
+// registered before them. Collectors that don't implement it depend on all built-in sections.
+type Dependent interface {
+	Dependencies() []Section
+}
+
+// Sections the collector depends on.
+func dependencies(c Collector) []Section {
+	if d, ok := c.(Dependent); ok {
+		return d.Dependencies()
+	}
+
+	deps := make([]Section, len(collectors))
+	for i, t := range collectors {
+		deps[i] = t.section
+	}
+
+	return deps
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Collector
+)
+
+// Register makes a custom collector run alongside built-in collectors. It is typically called from an init function.
+// If Register is called twice with the same name, with the name of a built-in section or any other SysInfo field, or
+// if the collector depends on an unknown section, it panics.
+func Register(c Collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if c == nil {
+		panic("sysinfo: Register collector is nil")
+	}
+
+	name := c.Name()
+	for _, t := range collectors {
+		if string(t.section) == name {
+			panic("sysinfo: Register collector for built-in section " + name)
+		}
+	}
+
+	// Names of SysInfo fields, even if not sections (like "sysinfo" for Meta), would be mistaken for them.
+	st := reflect.TypeOf(SysInfo{})
+	for i := 0; i < st.NumField(); i++ {
+		if f := st.Field(i); name == "" || name == jsonName(f) || strings.EqualFold(name, f.Name) {
+			panic("sysinfo: Register collector with reserved name " + strconv.Quote(name))
+		}
+	}
+	for _, r := range registry {
+		if r.Name() == name {
+			panic("sysinfo: Register called twice for collector " + name)
+		}
+	}
+
+	for _, dep := range dependencies(c) {
+		if !knownSection(dep) {
+			panic("sysinfo: Register collector " + name + " depends on unknown section " + string(dep))
+		}
+	}
+
+	registry = append(registry, c)
+}
+
+// Built-in section, or custom section already registered, registry must be locked.
+func knownSection(s Section) bool {
+	for _, t := range collectors {
+		if t.section == s {
+			return true
+		}
+	}
+	for _, r := range registry {
+		if r.Name() == string(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Built-in collectors followed by registered ones, in order of registration, so that every collector comes after the
+// collectors it depends on.
+func tasks(ctx context.Context) []task {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	all := append([]task{}, collectors...)
+	for _, c := range registry {
+		all = append(all, task{Section(c.Name()), dependencies(c), func(si *SysInfo, p *probe) {
+			v, err := c.Collect(ctx, si)
+			if err != nil {
+				p.fail("collect", "", err)
+			}
+			if v != nil {
+				si.Extensions = map[string]any{c.Name(): v}
+			}
+		}})
+	}
+
+	return all
+}
@@ -0,0 +1,116 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+// Collector built on top of a built-in section.
+type boardCollector struct{}
+
+func (boardCollector) Name() string { return "boardname" }
+
+func (boardCollector) Dependencies() []sysinfo.Section {
+	return []sysinfo.Section{sysinfo.SectionBoard}
+}
+
+func (boardCollector) Collect(_ context.Context, si *sysinfo.SysInfo) (any, error) {
+	return "board " + si.Board.Name, nil
+}
+
+// Collector reading Meta, which depends on nothing, so it runs while other sections are still being recorded.
+type metaCollector struct{}
+
+func (metaCollector) Name() string { return "recorded" }
+
+func (metaCollector) Dependencies() []sysinfo.Section { return nil }
+
+func (metaCollector) Collect(_ context.Context, si *sysinfo.SysInfo) (any, error) {
+	n := 0
+	for deadline := time.Now().Add(100 * time.Millisecond); time.Now().Before(deadline); {
+		n = len(si.Meta.Sections)
+		for range si.Meta.Sections {
+		}
+	}
+
+	return n, nil
+}
+
+func init() {
+	sysinfo.Register(boardCollector{})
+	sysinfo.Register(metaCollector{})
+}
+
+func TestRegisterCollect(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/dmi/id/board_name": "Test Board",
+	})
+
+	// Sections the collector depends on are gathered first, even if only the custom section is asked for.
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect("boardname")
+
+	if want := []sysinfo.Section{sysinfo.SectionBoard, "boardname"}; !reflect.DeepEqual(gathered(&si), want) {
+		t.Errorf("gathered sections = %v, want %v", gathered(&si), want)
+	}
+
+	if v := si.Extensions["boardname"]; v != "board Test Board" {
+		t.Errorf("Extensions[boardname] = %v, want %q", v, "board Test Board")
+	}
+	if si.Meta.Sections["boardname"] != sysinfo.StatusComplete {
+		t.Errorf("boardname status = %q, want %q", si.Meta.Sections["boardname"], sysinfo.StatusComplete)
+	}
+}
+
+// Run with -race, collectors must not see the section status being recorded as other collectors finish.
+func TestRegisterMeta(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/dmi/id/board_name": "Test Board",
+	})
+
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect()
+
+	if si.Meta.Sections["recorded"] != sysinfo.StatusComplete {
+		t.Errorf("recorded status = %q, want %q", si.Meta.Sections["recorded"], sysinfo.StatusComplete)
+	}
+}
+
+type namedCollector string
+
+func (c namedCollector) Name() string { return string(c) }
+
+func (namedCollector) Collect(context.Context, *sysinfo.SysInfo) (any, error) { return nil, nil }
+
+// Collector depending on a section that doesn't exist.
+type orphanCollector struct{ namedCollector }
+
+func (orphanCollector) Dependencies() []sysinfo.Section { return []sysinfo.Section{"bogus"} }
+
+func TestRegisterReserved(t *testing.T) {
+	for _, name := range []string{"", "cpu", "sysinfo", "meta", "node", "extensions", "root", "boardname"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) didn't panic", name)
+				}
+			}()
+			sysinfo.Register(namedCollector(name))
+		}()
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() with unknown dependency didn't panic")
+		}
+	}()
+	sysinfo.Register(orphanCollector{"orphan"})
+}
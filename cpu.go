@@ -6,23 +6,89 @@ package sysinfo
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
-	"os"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
+
+	"github.com/zcalusic/sysinfo/smbios"
 )
 
 // CPU information.
 type CPU struct {
-	Vendor  string `json:"vendor,omitempty"`
-	Model   string `json:"model,omitempty"`
-	Speed   uint   `json:"speed,omitempty"`   // CPU clock rate in MHz
-	Cache   uint   `json:"cache,omitempty"`   // CPU cache size in KB
-	Cpus    uint   `json:"cpus,omitempty"`    // number of physical CPUs
-	Cores   uint   `json:"cores,omitempty"`   // number of physical CPU cores
-	Threads uint   `json:"threads,omitempty"` // number of logical (HT) CPU cores
+	Vendor  string      `json:"vendor,omitempty"`
+	Model   string      `json:"model,omitempty"`
+	Speed   uint        `json:"speed,omitempty"`   // CPU clock rate in MHz
+	Cache   uint        `json:"cache,omitempty"`   // CPU cache size in KB
+	Cpus    uint        `json:"cpus,omitempty"`    // number of physical CPUs
+	Cores   uint        `json:"cores,omitempty"`   // number of physical CPU cores
+	Threads uint        `json:"threads,omitempty"` // number of logical (HT) CPU cores
+	Caches  []CPUCache  `json:"caches,omitempty"`
+	Sockets []CPUSocket `json:"sockets,omitempty"`
+}
+
+// CPUSocket information, one for every processor socket, including empty ones.
+type CPUSocket struct {
+	Socket          string   `json:"socket,omitempty"`
+	Empty           bool     `json:"empty,omitempty"` // no processor installed in the socket
+	Status          string   `json:"status,omitempty"`
+	Upgrade         string   `json:"upgrade,omitempty"` // socket type
+	Family          string   `json:"family,omitempty"`
+	Vendor          string   `json:"vendor,omitempty"`
+	Model           string   `json:"model,omitempty"`
+	ID              string   `json:"id,omitempty"`            // processor ID bytes, for x86 CPUID signature and features
+	Voltage         uint     `json:"voltage,omitempty"`       // mV
+	ExternalClock   uint     `json:"externalclock,omitempty"` // MHz
+	MaxSpeed        uint     `json:"maxspeed,omitempty"`      // MHz
+	Speed           uint     `json:"speed,omitempty"`         // MHz at boot time
+	Cores           uint     `json:"cores,omitempty"`
+	EnabledCores    uint     `json:"enabledcores,omitempty"`
+	Threads         uint     `json:"threads,omitempty"`
+	EnabledThreads  uint     `json:"enabledthreads,omitempty"`
+	Serial          string   `json:"serial,omitempty"`
+	AssetTag        string   `json:"assettag,omitempty"`
+	PartNumber      string   `json:"partnumber,omitempty"`
+	Characteristics []string `json:"characteristics,omitempty"`
+}
+
+func newCPUSocket(pr *smbios.Processor) CPUSocket {
+	cs := CPUSocket{
+		Socket:          pr.Socket,
+		Empty:           !pr.Status.Populated(),
+		Status:          pr.Status.String(),
+		Vendor:          pr.Manufacturer,
+		Model:           pr.Version,
+		Voltage:         uint(pr.Voltage),
+		ExternalClock:   uint(pr.ExternalClock),
+		MaxSpeed:        uint(pr.MaxSpeed),
+		Speed:           uint(pr.CurrentSpeed),
+		Cores:           uint(pr.CoreCount),
+		EnabledCores:    uint(pr.CoreEnabled),
+		Threads:         uint(pr.ThreadCount),
+		EnabledThreads:  uint(pr.ThreadEnabled),
+		Serial:          pr.SerialNumber,
+		AssetTag:        pr.AssetTag,
+		PartNumber:      pr.PartNumber,
+		Characteristics: pr.Characteristics.Strings(),
+	}
+
+	if pr.Upgrade != 0 {
+		cs.Upgrade = pr.Upgrade.String()
+	}
+	if pr.Family != 0 {
+		cs.Family = pr.Family.String()
+	}
+
+	if pr.ID != 0 {
+		id := make([]string, 8)
+		for i := range id {
+			id[i] = fmt.Sprintf("%02X", uint8(pr.ID>>(8*i)))
+		}
+		cs.ID = strings.Join(id, " ")
+	}
+
+	return cs
 }
 
 var (
@@ -31,21 +97,47 @@ var (
 	reCacheSize  = regexp.MustCompile(`^(\d+) KB$`)
 )
 
-func (si *SysInfo) getCPUInfo() {
-	si.CPU.Threads = uint(runtime.NumCPU())
+// Gather processor sockets, and CPU speed, from SMBIOS.
+func (si *SysInfo) getCPUSockets(p *probe) {
+	t := getSMBIOS(p)
+	if t == nil {
+		return
+	}
+
+	si.CPU.Speed = 0
+	si.CPU.Sockets = nil
+	for _, s := range t.Type(smbios.TypeProcessor) {
+		pr, err := s.Processor()
+		if err != nil {
+			p.failSMBIOS(err)
+			continue
+		}
+
+		si.CPU.Sockets = append(si.CPU.Sockets, newCPUSocket(pr))
+
+		if si.CPU.Speed == 0 {
+			si.CPU.Speed = uint(pr.CurrentSpeed)
+		}
+	}
+}
+
+func (si *SysInfo) getCPUInfo(p *probe) {
+	si.CPU.Threads = p.numCPU()
+	si.getCPUSockets(p)
+	si.getCPUCaches(p)
 
-	f, err := os.Open("/proc/cpuinfo")
+	cpuinfo, err := p.readFile("/proc/cpuinfo")
 	if err != nil {
+		p.failPath(err)
 		return
 	}
-	defer f.Close()
 
 	cpu := make(map[string]bool)
 	core := make(map[string]bool)
 
 	var cpuID string
 
-	s := bufio.NewScanner(f)
+	s := bufio.NewScanner(bytes.NewReader(cpuinfo))
 	for s.Scan() {
 		if sl := reTwoColumns.Split(s.Text(), 2); sl != nil {
 			switch sl[0] {
@@ -70,13 +162,16 @@ func (si *SysInfo) getCPUInfo() {
 					if m := reCacheSize.FindStringSubmatch(sl[1]); m != nil {
 						if cache, err := strconv.ParseUint(m[1], 10, 64); err == nil {
 							si.CPU.Cache = uint(cache)
+						} else {
+							p.failParse("/proc/cpuinfo", err)
 						}
 					}
 				}
 			}
 		}
 	}
-	if s.Err() != nil {
+	if err := s.Err(); err != nil {
+		p.failPath(err)
 		return
 	}
 
@@ -0,0 +1,195 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// CPUCache information, one for every cache instance (caches shared by many CPUs are listed once).
+type CPUCache struct {
+	Level      uint   `json:"level,omitempty"`
+	Type       string `json:"type,omitempty"`       // Data, Instruction or Unified
+	Size       uint   `json:"size,omitempty"`       // cache size in KB
+	Ways       uint   `json:"ways,omitempty"`       // ways of associativity
+	LineSize   uint   `json:"linesize,omitempty"`   // coherency line size in bytes
+	Sets       uint   `json:"sets,omitempty"`       // number of sets
+	SharedCPUs string `json:"sharedcpus,omitempty"` // list of logical CPUs sharing the cache, like "0-3,8-11"
+	SMBIOSSize uint   `json:"smbiossize,omitempty"` // cache size in KB reported by SMBIOS, only if it doesn't match
+}
+
+func newCPUCache(c *smbios.Cache) CPUCache {
+	cc := CPUCache{
+		Level: uint(c.Level),
+		Size:  uint(c.InstalledSize >> 10),
+		Ways:  c.Associativity.Ways(),
+	}
+
+	// Other and Unknown types are left out, sysfs knows only about Instruction, Data and Unified caches.
+	if c.Type > 2 {
+		cc.Type = c.Type.String()
+	}
+
+	return cc
+}
+
+var reCPUDir = regexp.MustCompile(`^cpu(\d+)$`)
+
+// Gather CPU caches from SMBIOS, which lists them per processor socket.
+func smbiosCPUCaches(p *probe) []CPUCache {
+	t := getSMBIOS(p)
+	if t == nil {
+		return nil
+	}
+
+	var caches []CPUCache
+	for _, s := range t.Type(smbios.TypeCache) {
+		c, err := s.Cache()
+		if err != nil {
+			p.failSMBIOS(err)
+			continue
+		}
+
+		if c.Enabled && c.InstalledSize > 0 {
+			caches = append(caches, newCPUCache(c))
+		}
+	}
+
+	return caches
+}
+
+// Gather CPU caches from sysfs. Caches are listed under every CPU sharing them, so they're deduplicated by the list of
+// sharing CPUs (or by CPU, if the kernel doesn't export the list).
+func sysfsCPUCaches(p *probe) []CPUCache {
+	sysCPU := "/sys/devices/system/cpu"
+	entries, err := p.readDir(sysCPU)
+	if err != nil {
+		p.failPath(err)
+		return nil
+	}
+
+	var cpus []int
+	for _, e := range entries {
+		if m := reCPUDir.FindStringSubmatch(e.Name()); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			cpus = append(cpus, n)
+		}
+	}
+	slices.Sort(cpus)
+
+	var caches []CPUCache
+	seen := make(map[string]bool)
+	for _, cpu := range cpus {
+		cacheDir := path.Join(sysCPU, "cpu"+strconv.Itoa(cpu), "cache")
+		indexes, err := p.readDir(cacheDir)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				p.failPath(err)
+			}
+			continue
+		}
+
+		for _, index := range indexes {
+			if !strings.HasPrefix(index.Name(), "index") {
+				continue
+			}
+
+			dir := path.Join(cacheDir, index.Name())
+			c := CPUCache{
+				Level:      p.slurpUint(path.Join(dir, "level")),
+				Type:       p.slurpOptional(path.Join(dir, "type")),
+				Ways:       p.slurpUint(path.Join(dir, "ways_of_associativity")),
+				LineSize:   p.slurpUint(path.Join(dir, "coherency_line_size")),
+				Sets:       p.slurpUint(path.Join(dir, "number_of_sets")),
+				SharedCPUs: p.slurpOptional(path.Join(dir, "shared_cpu_list")),
+			}
+
+			shared := c.SharedCPUs
+			if shared == "" {
+				shared = strconv.Itoa(cpu)
+			}
+			key := strconv.Itoa(int(c.Level)) + "/" + c.Type + "/" + shared
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if size := p.slurpOptional(path.Join(dir, "size")); size != "" {
+				if kb, err := strconv.ParseUint(strings.TrimSuffix(size, "K"), 10, 64); err == nil {
+					c.Size = uint(kb)
+				} else {
+					p.failParse(path.Join(dir, "size"), err)
+				}
+			}
+
+			caches = append(caches, c)
+		}
+	}
+
+	slices.SortStableFunc(caches, func(a, b CPUCache) int {
+		return int(a.Level) - int(b.Level)
+	})
+
+	return caches
+}
+
+// Gather CPU caches from sysfs, cross-checked against SMBIOS. SMBIOS fills in what sysfs doesn't know (some ARM
+// systems), or is used instead of it, if there's no cache information in sysfs at all. Sizes that don't match are
+// reported (see CPUCache.SMBIOSSize).
+func (si *SysInfo) getCPUCaches(p *probe) {
+	smbiosCaches := smbiosCPUCaches(p)
+	caches := sysfsCPUCaches(p)
+	if len(caches) == 0 {
+		si.CPU.Caches = smbiosCaches
+		return
+	}
+
+	for i := range caches {
+		c := &caches[i]
+		var matching []CPUCache
+		for _, sc := range smbiosCaches {
+			if sc.Level == c.Level && (sc.Type == c.Type || sc.Type == "") {
+				matching = append(matching, sc)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		if c.Ways == 0 {
+			c.Ways = matching[0].Ways
+		}
+
+		// Sizes can be compared only if sysfs and SMBIOS count cache instances the same way.
+		if len(matching) == countCaches(caches, c.Level, c.Type) {
+			switch {
+			case c.Size == 0:
+				c.Size = matching[0].Size
+			case c.Size != matching[0].Size:
+				c.SMBIOSSize = matching[0].Size
+			}
+		}
+	}
+
+	si.CPU.Caches = caches
+}
+
+func countCaches(caches []CPUCache, level uint, typ string) (n int) {
+	for _, c := range caches {
+		if c.Level == level && c.Type == typ {
+			n++
+		}
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestCPUCaches(t *testing.T) {
+	// SMBIOS type 7: 8-way 32K L1 data cache, 8-way 512K L2 unified cache, followed by end-of-table.
+	table := "\x07\x13\x00\x07\x01\x80\x00\x20\x00\x20\x00\x00\x00\x00\x00\x00\x00\x04\x07L1 Cache\x00\x00" +
+		"\x07\x13\x01\x07\x01\x81\x00\x00\x02\x00\x02\x00\x00\x00\x00\x00\x00\x05\x07L2 Cache\x00\x00" +
+		"\x7f\x04\xff\xff\x00\x00"
+
+	root := fixture(t, map[string]string{
+		"sys/firmware/dmi/tables/DMI": table,
+
+		// No shared CPU list, L1 caches are private to every CPU.
+		"sys/devices/system/cpu/cpu0/cache/index0/level":               "1",
+		"sys/devices/system/cpu/cpu0/cache/index0/type":                "Data",
+		"sys/devices/system/cpu/cpu0/cache/index0/size":                "32K",
+		"sys/devices/system/cpu/cpu0/cache/index0/coherency_line_size": "64",
+		"sys/devices/system/cpu/cpu0/cache/index0/number_of_sets":      "64",
+		"sys/devices/system/cpu/cpu1/cache/index0/level":               "1",
+		"sys/devices/system/cpu/cpu1/cache/index0/type":                "Data",
+		"sys/devices/system/cpu/cpu1/cache/index0/size":                "32K",
+		"sys/devices/system/cpu/cpu1/cache/index0/coherency_line_size": "64",
+		"sys/devices/system/cpu/cpu1/cache/index0/number_of_sets":      "64",
+
+		// L2 cache shared by both CPUs, listed under both.
+		"sys/devices/system/cpu/cpu0/cache/index2/level":                 "2",
+		"sys/devices/system/cpu/cpu0/cache/index2/type":                  "Unified",
+		"sys/devices/system/cpu/cpu0/cache/index2/size":                  "1024K",
+		"sys/devices/system/cpu/cpu0/cache/index2/ways_of_associativity": "16",
+		"sys/devices/system/cpu/cpu0/cache/index2/shared_cpu_list":       "0-1",
+		"sys/devices/system/cpu/cpu1/cache/index2/level":                 "2",
+		"sys/devices/system/cpu/cpu1/cache/index2/type":                  "Unified",
+		"sys/devices/system/cpu/cpu1/cache/index2/size":                  "1024K",
+		"sys/devices/system/cpu/cpu1/cache/index2/ways_of_associativity": "16",
+		"sys/devices/system/cpu/cpu1/cache/index2/shared_cpu_list":       "0-1",
+	})
+
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect(sysinfo.SectionCPU)
+
+	// Ways missing in sysfs come from SMBIOS, sizes are compared only if instances are counted the same way.
+	want := []sysinfo.CPUCache{
+		{Level: 1, Type: "Data", Size: 32, Ways: 8, LineSize: 64, Sets: 64},
+		{Level: 1, Type: "Data", Size: 32, Ways: 8, LineSize: 64, Sets: 64},
+		{Level: 2, Type: "Unified", Size: 1024, Ways: 16, SharedCPUs: "0-1", SMBIOSSize: 512},
+	}
+	if !reflect.DeepEqual(si.CPU.Caches, want) {
+		t.Errorf("CPU.Caches = %+v, want %+v", si.CPU.Caches, want)
+	}
+}
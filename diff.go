@@ -0,0 +1,275 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind tells how a value changed between two snapshots.
+type ChangeKind string
+
+// Kinds of changes.
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+	Changed ChangeKind = "changed"
+)
+
+// Change between two SysInfo snapshots.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	Path string     `json:"path"` // JSON field path, list elements are addressed by key, e.g. storage[9XF2HZ9K].size
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s: %v", c.Path, c.New)
+	case Removed:
+		return fmt.Sprintf("- %s: %v", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %v -> %v", c.Path, c.Old, c.New)
+	}
+}
+
+// List elements that implement keyer are matched by key, instead of by position.
+type keyer interface {
+	diffKey() string
+}
+
+// Storage devices are matched by serial number, as device names can change between boots.
+func (d StorageDevice) diffKey() string {
+	if d.Serial != "" {
+		return d.Serial
+	}
+
+	return d.Name
+}
+
+// CPU sockets are matched by socket designation.
+func (c CPUSocket) diffKey() string {
+	return c.Socket
+}
+
+// Memory modules are matched by socket, so that replaced modules show up as changed serial numbers.
+func (m MemoryModule) diffKey() string {
+	return m.BankLocator + "/" + m.Locator
+}
+
+// Slots are matched by designation, as printed on the motherboard.
+func (s Slot) diffKey() string {
+	return s.Designation
+}
+
+// Firmware components are matched by name, so that updates show up as changed versions.
+func (c FirmwareComponent) diffKey() string {
+	return c.Name
+}
+
+// Network devices are matched by MAC address, as interface names can change between boots.
+func (d NetworkDevice) diffKey() string {
+	if d.PermanentMAC != "" {
+		return d.PermanentMAC
+	}
+	if d.MACAddress != "" {
+		return d.MACAddress
+	}
+
+	return d.Name
+}
+
+// Diff returns changes between snapshots a and b, ignoring meta information (version & timestamp).
+func Diff(a, b *SysInfo) []Change {
+	var d differ
+
+	va := reflect.ValueOf(a).Elem()
+	vb := reflect.ValueOf(b).Elem()
+	t := va.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" || name == "sysinfo" {
+			continue
+		}
+
+		d.diff(name, va.Field(i), vb.Field(i))
+	}
+
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(kind ChangeKind, path string, old, new reflect.Value) {
+	c := Change{Kind: kind, Path: path}
+	if old.IsValid() {
+		c.Old = old.Interface()
+	}
+	if new.IsValid() {
+		c.New = new.Interface()
+	}
+
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	// Values with their own JSON representation are compared as a whole.
+	if a.Type().Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) ||
+		a.Type().Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()) {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(Changed, path, a, b)
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if name := jsonName(t.Field(i)); name != "" {
+				d.diff(path+"."+name, a.Field(i), b.Field(i))
+			}
+		}
+	case reflect.Pointer:
+		switch {
+		case a.IsNil() && b.IsNil():
+		case a.IsNil():
+			d.add(Added, path, reflect.Value{}, b.Elem())
+		case b.IsNil():
+			d.add(Removed, path, a.Elem(), reflect.Value{})
+		default:
+			d.diff(path, a.Elem(), b.Elem())
+		}
+	case reflect.Interface:
+		switch {
+		case a.IsNil() && b.IsNil():
+		case a.IsNil():
+			d.add(Added, path, reflect.Value{}, b.Elem())
+		case b.IsNil():
+			d.add(Removed, path, a.Elem(), reflect.Value{})
+		case a.Elem().Type() == b.Elem().Type():
+			d.diff(path, a.Elem(), b.Elem())
+		default:
+			d.add(Changed, path, a.Elem(), b.Elem())
+		}
+	case reflect.Map:
+		d.diffMap(path, a, b)
+	case reflect.Slice:
+		if a.Type().Elem().Implements(reflect.TypeOf((*keyer)(nil)).Elem()) {
+			d.diffKeyed(path, a, b)
+		} else if a.Type().Elem().Kind() == reflect.Struct {
+			d.diffIndexed(path, a, b)
+		} else if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(Changed, path, a, b)
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(Changed, path, a, b)
+		}
+	}
+}
+
+func (d *differ) diffMap(path string, a, b reflect.Value) {
+	keys := make(map[string]reflect.Value)
+	for _, k := range a.MapKeys() {
+		keys[fmt.Sprint(k.Interface())] = k
+	}
+	for _, k := range b.MapKeys() {
+		keys[fmt.Sprint(k.Interface())] = k
+	}
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		va := a.MapIndex(keys[name])
+		vb := b.MapIndex(keys[name])
+		switch {
+		case !va.IsValid():
+			d.add(Added, path+"."+name, reflect.Value{}, vb)
+		case !vb.IsValid():
+			d.add(Removed, path+"."+name, va, reflect.Value{})
+		default:
+			d.diff(path+"."+name, va, vb)
+		}
+	}
+}
+
+func (d *differ) diffKeyed(path string, a, b reflect.Value) {
+	index := func(v reflect.Value) ([]string, map[string]reflect.Value) {
+		var keys []string
+		m := make(map[string]reflect.Value)
+		for i := 0; i < v.Len(); i++ {
+			key := v.Index(i).Interface().(keyer).diffKey()
+			// Duplicate keys are disambiguated by order of appearance.
+			k := key
+			for n := 2; m[k].IsValid(); n++ {
+				k = fmt.Sprintf("%s#%d", key, n)
+			}
+			keys = append(keys, k)
+			m[k] = v.Index(i)
+		}
+		return keys, m
+	}
+
+	keysA, ma := index(a)
+	keysB, mb := index(b)
+
+	for _, k := range keysA {
+		if vb, ok := mb[k]; ok {
+			d.diff(path+"["+k+"]", ma[k], vb)
+		} else {
+			d.add(Removed, path+"["+k+"]", ma[k], reflect.Value{})
+		}
+	}
+	for _, k := range keysB {
+		if _, ok := ma[k]; !ok {
+			d.add(Added, path+"["+k+"]", reflect.Value{}, mb[k])
+		}
+	}
+}
+
+func (d *differ) diffIndexed(path string, a, b reflect.Value) {
+	for i := 0; i < a.Len() || i < b.Len(); i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= a.Len():
+			d.add(Added, p, reflect.Value{}, b.Index(i))
+		case i >= b.Len():
+			d.add(Removed, p, a.Index(i), reflect.Value{})
+		default:
+			d.diff(p, a.Index(i), b.Index(i))
+		}
+	}
+}
+
+// Name of the struct field in JSON output, empty if it's not serialized.
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(f.Name)
+	}
+
+	return name
+}
@@ -0,0 +1,51 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestDiff(t *testing.T) {
+	a := sysinfo.SysInfo{
+		BIOS: sysinfo.BIOS{Vendor: "Dell Inc.", Version: "2.1.0"},
+		Storage: []sysinfo.StorageDevice{
+			{Name: "sda", Serial: "AAA", Size: 480},
+			{Name: "sdb", Serial: "BBB", Size: 480},
+		},
+		Network: []sysinfo.NetworkDevice{
+			{Name: "eth0", MACAddress: "00:11:22:33:44:55", Speed: 1000},
+		},
+	}
+	b := sysinfo.SysInfo{
+		BIOS: sysinfo.BIOS{Vendor: "Dell Inc.", Version: "2.2.1"},
+		Storage: []sysinfo.StorageDevice{
+			{Name: "sda", Serial: "BBB", Size: 480},
+			{Name: "sdb", Serial: "CCC", Size: 960},
+		},
+		Network: []sysinfo.NetworkDevice{
+			{Name: "eno1", MACAddress: "00:11:22:33:44:55", Speed: 1000},
+		},
+	}
+
+	want := []sysinfo.Change{
+		{Kind: sysinfo.Changed, Path: "bios.version", Old: "2.1.0", New: "2.2.1"},
+		{Kind: sysinfo.Removed, Path: "storage[AAA]", Old: a.Storage[0]},
+		{Kind: sysinfo.Changed, Path: "storage[BBB].name", Old: "sdb", New: "sda"},
+		{Kind: sysinfo.Added, Path: "storage[CCC]", New: b.Storage[1]},
+		{Kind: sysinfo.Changed, Path: "network[00:11:22:33:44:55].name", Old: "eth0", New: "eno1"},
+	}
+
+	if got := sysinfo.Diff(&a, &b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+
+	if got := sysinfo.Diff(&a, &a); len(got) != 0 {
+		t.Errorf("Diff() of identical snapshots = %v, want none", got)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// SMBIOS table shared by all collectors of a single run, read and parsed only once.
+type dmiTable struct {
+	once  sync.Once
+	table *smbios.Table
+	errs  Errors // failures reading the table, reported once, as collector "smbios"
+}
+
+// Table read on behalf of the collector, its failures don't affect the collector's section.
+func (d *dmiTable) read(p *probe) *smbios.Table {
+	d.once.Do(func() {
+		dp := &probe{si: p.si, collector: "smbios"}
+		d.table = readSMBIOS(dp)
+		d.errs = dp.errs
+	})
+	p.dmiRead = true
+
+	return d.table
+}
+
+// SMBIOS table, nil if it can't be read. Failures to read the table count toward the status of the collector's
+// section, as the section is (mostly) gathered from the table.
+func getSMBIOS(p *probe) *smbios.Table {
+	p.dmiUsed = true
+	return p.dmi.read(p)
+}
+
+// Path of the SMBIOS table collectors decode, either the configured dump, or the table exported by the kernel.
+func (p *probe) smbiosPath() string {
+	if p.si.SMBIOS != "" {
+		return p.si.SMBIOS
+	}
+
+	return smbios.SysfsTable
+}
+
+// Record SMBIOS structure that couldn't be decoded.
+func (p *probe) failSMBIOS(err error) {
+	p.failParse(p.smbiosPath(), err)
+}
+
+// Read and parse SMBIOS table (or the configured dump), nil if it can't be read. The entry point is optional, older
+// kernels don't export it.
+func readSMBIOS(p *probe) *smbios.Table {
+	if p.si.SMBIOS != "" {
+		data, err := p.readFile(p.si.SMBIOS)
+		if err != nil {
+			p.failPath(err)
+			return nil
+		}
+
+		t, err := smbios.ParseDump(data)
+		if err != nil {
+			p.failSMBIOS(err)
+		}
+
+		return t
+	}
+
+	table, err := p.readFile(smbios.SysfsTable)
+	if err != nil {
+		p.failPath(err)
+		return nil
+	}
+
+	entryPoint, err := p.readFile(smbios.SysfsEntryPoint)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		p.failPath(err)
+	}
+
+	t, err := smbios.Parse(entryPoint, table)
+	if err != nil {
+		p.failSMBIOS(err)
+	}
+
+	return t
+}
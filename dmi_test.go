@@ -0,0 +1,124 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+// Dump SMBIOS table the way "dmidecode --dump-bin" does, 64-bit entry point patched to point to the table at 0x20.
+func dumpBin(table string) []byte {
+	ep := make([]byte, 0x20)
+	copy(ep, "_SM3_")
+	ep[0x06], ep[0x07], ep[0x08], ep[0x0a] = 0x18, 3, 5, 1
+	binary.LittleEndian.PutUint32(ep[0x0c:], uint32(len(table)))
+	binary.LittleEndian.PutUint64(ep[0x10:], 0x20)
+
+	var sum uint8
+	for _, b := range ep[:0x18] {
+		sum += b
+	}
+	ep[0x05] = -sum
+
+	return append(ep, table...)
+}
+
+// Build SMBIOS structure, the same way the smbios package tests do.
+func structure(typ uint8, handle uint16, formatted []byte, strings ...string) string {
+	data := []byte{typ, uint8(4 + len(formatted)), 0, 0}
+	binary.LittleEndian.PutUint16(data[2:], handle)
+	data = append(data, formatted...)
+	for _, s := range strings {
+		data = append(data, s...)
+		data = append(data, 0)
+	}
+	if len(strings) == 0 {
+		data = append(data, 0)
+	}
+
+	return string(append(data, 0))
+}
+
+// Build formatted area of the given length, with values set at offsets (relative to the start of the structure).
+func formatted(length int, values map[int]any) []byte {
+	data := make([]byte, length)
+	for offset, v := range values {
+		switch v := v.(type) {
+		case uint8:
+			data[offset] = v
+		case uint16:
+			binary.LittleEndian.PutUint16(data[offset:], v)
+		case uint32:
+			binary.LittleEndian.PutUint32(data[offset:], v)
+		case uint64:
+			binary.LittleEndian.PutUint64(data[offset:], v)
+		}
+	}
+
+	return data[4:]
+}
+
+// End-of-table structure.
+var endOfTable = structure(127, 0xffff, nil)
+
+func TestSMBIOSDump(t *testing.T) {
+	// OEM strings, followed by end-of-table.
+	dump := dumpBin("\x0b\x05\x00\x0b\x01customer\x00\x00\x7f\x04\xff\xff\x00\x00")
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "dump.bin"), dump, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	live := sysinfo.SysInfo{Root: root, SMBIOS: "/dump.bin", Capture: &sysinfo.Capture{}}
+	_ = live.Collect(sysinfo.SectionProduct)
+	if want := []string{"customer"}; !reflect.DeepEqual(live.Product.OEMStrings, want) {
+		t.Errorf("Product.OEMStrings = %q, want %q", live.Product.OEMStrings, want)
+	}
+
+	// The dump is captured, and replayed, like any other file.
+	var buf bytes.Buffer
+	if _, err := live.Capture.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	capture, err := sysinfo.ReadCapture(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := sysinfo.SysInfo{Replay: capture, SMBIOS: "/dump.bin"}
+	_ = replayed.Collect(sysinfo.SectionProduct)
+	if !reflect.DeepEqual(replayed.Product.OEMStrings, live.Product.OEMStrings) {
+		t.Errorf("replayed Product.OEMStrings = %q, want %q", replayed.Product.OEMStrings, live.Product.OEMStrings)
+	}
+
+	// Relative path is relative to the root of the capture, no matter the current directory.
+	replayed = sysinfo.SysInfo{Replay: capture, SMBIOS: "dump.bin"}
+	_ = replayed.Collect(sysinfo.SectionProduct)
+	if !reflect.DeepEqual(replayed.Product.OEMStrings, live.Product.OEMStrings) {
+		t.Errorf("replayed Product.OEMStrings = %q, want %q", replayed.Product.OEMStrings, live.Product.OEMStrings)
+	}
+
+	// Failures are reported against the dump.
+	if err := os.WriteFile(filepath.Join(root, "dump.bin"), dump[:0x28], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	si := sysinfo.SysInfo{Root: root, SMBIOS: "/dump.bin"}
+	err = si.Collect(sysinfo.SectionSlots)
+
+	var errs sysinfo.Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "/dump.bin" || !errors.Is(errs[0], sysinfo.ErrParse) {
+		t.Errorf("Collect() = %v, want parse error of /dump.bin", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// ErrParse is reported when a file or table was read successfully, but its content could not be understood.
+var ErrParse = errors.New("parse error")
+
+// CollectorError describes a single failure encountered while gathering information.
+//
+// Use errors.Is with fs.ErrNotExist, fs.ErrPermission or ErrParse to find out why it failed.
+type CollectorError struct {
+	Collector string // collector that failed, e.g. "memory"
+	Op        string // operation that failed, e.g. "open", "readlink", "ioctl", "parse"
+	Path      string // file path or device name the operation was applied to
+	Err       error  // underlying error
+}
+
+func (e *CollectorError) Error() string {
+	s := e.Collector + ": " + e.Op
+	if e.Path != "" {
+		s += " " + e.Path
+	}
+
+	return s + ": " + e.Err.Error()
+}
+
+func (e *CollectorError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON encodes the error as a flat JSON object, suitable for reporting.
+func (e *CollectorError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Collector string `json:"collector"`
+		Op        string `json:"op"`
+		Path      string `json:"path,omitempty"`
+		Error     string `json:"error"`
+	}{e.Collector, e.Op, e.Path, e.Err.Error()})
+}
+
+// Errors is a list of failures, as returned by Collect.
+type Errors []*CollectorError
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+
+	return strings.Join(s, "\n")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+func (p *probe) fail(op, path string, err error) {
+	p.errs = append(p.errs, &CollectorError{Collector: p.collector, Op: op, Path: path, Err: err})
+}
+
+// Record failed file system operation.
+func (p *probe) failPath(err error) {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		p.fail(pe.Op, pe.Path, pe.Err)
+		return
+	}
+
+	p.fail("read", "", err)
+}
+
+// Record content that couldn't be parsed, err may be nil.
+func (p *probe) failParse(path string, err error) {
+	if err == nil {
+		p.fail("parse", path, ErrParse)
+		return
+	}
+
+	p.fail("parse", path, fmt.Errorf("%w: %w", ErrParse, err))
+}
+
+// Whether not finding something is conclusive, nothing failed but missing files (which is what absence looks like),
+// including reading the SMBIOS table, if the collector's section depends on it.
+func (p *probe) conclusive() bool {
+	errs := p.errs
+	if p.dmiUsed {
+		errs = append(errs[:len(errs):len(errs)], p.dmi.errs...)
+	}
+
+	for _, err := range errs {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false
+		}
+	}
+
+	return true
+}
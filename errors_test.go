@@ -0,0 +1,99 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+// Create root file system with the given files (paths relative to root), newline is added to every file.
+func fixture(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, data := range files {
+		name = filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(data+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestCollectErrors(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/dmi/id/chassis_type": "tower",
+		"sys/class/dmi/id/product_name": "Test",
+		"sys/class/dmi/id/product_uuid": "not-an-uuid",
+	})
+
+	si := sysinfo.SysInfo{Root: root, ReadOnly: true}
+	err := si.Collect(sysinfo.SectionChassis, sysinfo.SectionProduct)
+
+	var errs sysinfo.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Collect() = %v, want Errors", err)
+	}
+
+	parse := make(map[string]string)
+	for _, e := range errs {
+		if !strings.HasPrefix(e.Path, "/sys/") {
+			t.Errorf("failure %v not reported against path as seen under root", e)
+		}
+
+		switch {
+		case errors.Is(e, sysinfo.ErrParse):
+			parse[e.Collector] = e.Path
+		case !errors.Is(e, fs.ErrNotExist):
+			t.Errorf("unexpected failure %v", e)
+		}
+	}
+
+	want := map[string]string{
+		"chassis": "/sys/class/dmi/id/chassis_type",
+		"product": "/sys/class/dmi/id/product_uuid",
+	}
+	if !reflect.DeepEqual(parse, want) {
+		t.Errorf("parse failures = %v, want %v", parse, want)
+	}
+	if si.Product.Name != "Test" {
+		t.Errorf("Product.Name = %q, want %q", si.Product.Name, "Test")
+	}
+}
+
+func TestSMBIOSErrorsOnce(t *testing.T) {
+	si := sysinfo.SysInfo{Root: fixture(t, nil)}
+	err := si.Collect(sysinfo.SectionMemory, sysinfo.SectionSlots, sysinfo.SectionBMC, sysinfo.SectionFirmware)
+
+	var errs sysinfo.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Collect() = %v, want Errors", err)
+	}
+
+	n := 0
+	for _, e := range errs {
+		if e.Path == "/sys/firmware/dmi/tables/DMI" {
+			n++
+			if e.Collector != "smbios" || !errors.Is(e, fs.ErrNotExist) {
+				t.Errorf("unexpected failure %v", e)
+			}
+		}
+	}
+	if n != 1 {
+		t.Errorf("missing SMBIOS table reported %d times, want once", n)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// FingerprintVersion is the version of the algorithm used by Fingerprint. It changes whenever the algorithm changes in
+// a way that changes fingerprints, and is part of the fingerprint itself.
+const FingerprintVersion = "v1"
+
+// FingerprintComponent is a hardware identifier fingerprint can be derived from.
+type FingerprintComponent string
+
+// Fingerprint components, in the order they're fed to the hash.
+const (
+	FingerprintProductUUID   FingerprintComponent = "product.uuid"
+	FingerprintProductSerial FingerprintComponent = "product.serial"
+	FingerprintBoardSerial   FingerprintComponent = "board.serial"
+	FingerprintChassisSerial FingerprintComponent = "chassis.serial"
+	FingerprintStorageSerial FingerprintComponent = "storage.serial"
+	FingerprintNetworkMAC    FingerprintComponent = "network.permanentmac"
+)
+
+var fingerprintComponents = []FingerprintComponent{
+	FingerprintProductUUID,
+	FingerprintProductSerial,
+	FingerprintBoardSerial,
+	FingerprintChassisSerial,
+	FingerprintStorageSerial,
+	FingerprintNetworkMAC,
+}
+
+// ErrNoFingerprint is returned by Fingerprint when none of the components has a usable value.
+var ErrNoFingerprint = errors.New("no hardware identifiers to fingerprint")
+
+// ErrUnknownFingerprintComponent is returned by Fingerprint when asked for a component that doesn't exist.
+var ErrUnknownFingerprintComponent = errors.New("unknown fingerprint component")
+
+// Fingerprint returns a stable hardware identity, derived from the given components (or all of them, if none are
+// given), an unknown component is an error. As it's derived from hardware only, it survives OS reinstalls, but changes
+// when identifying hardware (disks, NICs, motherboard) is replaced. Leave out components that are expected to change,
+// like storage on machines that boot from removable disks.
+//
+// The algorithm (version v1) is:
+//
+//   - values of every component are collected, serial numbers are upper cased, UUIDs and MAC addresses lower cased,
+//   - empty and well-known placeholder values ("To Be Filled By O.E.M.", all-zero UUIDs, ...) are dropped,
+//   - values of multi-valued components (storage, network) are sorted and deduplicated,
+//   - "component=value\n" lines are fed to SHA-256, components in the order of the FingerprintComponent constants,
+//   - the fingerprint is FingerprintVersion, followed by a colon and the hex encoded hash.
+//
+// Physical network devices without a permanent MAC address (as reported by the driver) don't contribute.
+func (si *SysInfo) Fingerprint(components ...FingerprintComponent) (string, error) {
+	if len(components) == 0 {
+		components = fingerprintComponents
+	}
+
+	wanted := make(map[FingerprintComponent]bool)
+	for _, c := range components {
+		if !slices.Contains(fingerprintComponents, c) {
+			return "", fmt.Errorf("%w %q", ErrUnknownFingerprintComponent, c)
+		}
+		wanted[c] = true
+	}
+
+	h := sha256.New()
+	empty := true
+	for _, c := range fingerprintComponents {
+		if !wanted[c] {
+			continue
+		}
+
+		for _, v := range si.fingerprintValues(c) {
+			_, _ = h.Write([]byte(string(c) + "=" + v + "\n"))
+			empty = false
+		}
+	}
+
+	if empty {
+		return "", ErrNoFingerprint
+	}
+
+	return FingerprintVersion + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Usable values of a component, normalized, sorted and deduplicated.
+func (si *SysInfo) fingerprintValues(c FingerprintComponent) []string {
+	var values []string
+	switch c {
+	case FingerprintProductUUID:
+		values = append(values, strings.ToLower(si.Product.UUID.String()))
+	case FingerprintProductSerial:
+		values = append(values, strings.ToUpper(si.Product.Serial))
+	case FingerprintBoardSerial:
+		values = append(values, strings.ToUpper(si.Board.Serial))
+	case FingerprintChassisSerial:
+		values = append(values, strings.ToUpper(si.Chassis.Serial))
+	case FingerprintStorageSerial:
+		for _, d := range si.Storage {
+			values = append(values, strings.ToUpper(d.Serial))
+		}
+	case FingerprintNetworkMAC:
+		for _, d := range si.Network {
+			values = append(values, strings.ToLower(d.PermanentMAC))
+		}
+	}
+
+	seen := make(map[string]bool)
+	usable := values[:0]
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if !isBogus(v) && !seen[v] {
+			seen[v] = true
+			usable = append(usable, v)
+		}
+	}
+	sort.Strings(usable)
+
+	return usable
+}
+
+// Placeholders commonly found in place of real serial numbers, lower cased.
+var bogusValues = map[string]bool{
+	"to be filled by o.e.m.":               true,
+	"to be filled by oem":                  true,
+	"default string":                       true,
+	"system serial number":                 true,
+	"chassis serial number":                true,
+	"base board serial number":             true,
+	"serial number":                        true,
+	"not specified":                        true,
+	"not applicable":                       true,
+	"not available":                        true,
+	"not settable":                         true,
+	"unknown":                              true,
+	"invalid":                              true,
+	"none":                                 true,
+	"n/a":                                  true,
+	"na":                                   true,
+	"oem":                                  true,
+	"o.e.m.":                               true,
+	"0123456789":                           true,
+	"123456789":                            true,
+	"1234567890":                           true,
+	"03000200-0400-0500-0006-000700080009": true, // UUID found on many cheap motherboards
+	"00020003-0004-0005-0006-000700080009": true, // the same one, byte swapped
+}
+
+// Report well-known placeholder values, and values consisting of one repeated character (all-zero UUIDs and MAC
+// addresses, "FFFFFFFF", "........", ...), separators ignored.
+func isBogus(s string) bool {
+	if s == "" || bogusValues[strings.ToLower(s)] {
+		return true
+	}
+
+	s = strings.NewReplacer("-", "", ":", "", ".", "", " ", "").Replace(strings.ToLower(s))
+	return s == "" || strings.Trim(s, s[:1]) == ""
+}
@@ -0,0 +1,57 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestFingerprint(t *testing.T) {
+	si := sysinfo.SysInfo{
+		Product: sysinfo.Product{Serial: "CZ12345678", UUID: uuid.MustParse("4c4c4544-0042-3510-8052-b4c04f4e4b32")},
+		Board:   sysinfo.Board{Serial: "To Be Filled By O.E.M."},
+		Chassis: sysinfo.Chassis{Serial: "0000000000"},
+		Storage: []sysinfo.StorageDevice{{Name: "sdb", Serial: "WD-WCC4N1234567"}, {Name: "sda", Serial: "s3z1nb0k123456"}},
+		Network: []sysinfo.NetworkDevice{{Name: "eth0", MACAddress: "02:00:00:00:00:01", PermanentMAC: "00:11:22:33:44:55"}},
+	}
+
+	// Pinned, as fingerprints must not change within a FingerprintVersion.
+	const want = "v1:51d25619d0206cd83fdc3d3cc1e02fa9b7d1720a6702753b1b595d348404443f"
+
+	got, err := si.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Fingerprint() = %q, want %q", got, want)
+	}
+
+	// Order of devices, names, placeholder serials and current MAC addresses don't matter.
+	si.Storage = []sysinfo.StorageDevice{
+		{Name: "sda", Serial: "S3Z1NB0K123456"},
+		{Name: "sdc", Serial: "WD-WCC4N1234567"},
+	}
+	si.Board.Serial = ""
+	si.Network[0].MACAddress = "02:00:00:00:00:02"
+	if got, _ := si.Fingerprint(); got != want {
+		t.Errorf("Fingerprint() = %q after irrelevant changes, want %q", got, want)
+	}
+
+	empty := sysinfo.SysInfo{Product: sysinfo.Product{Serial: "System Serial Number"}}
+	_, err = empty.Fingerprint(sysinfo.FingerprintProductUUID, sysinfo.FingerprintProductSerial)
+	if !errors.Is(err, sysinfo.ErrNoFingerprint) {
+		t.Errorf("Fingerprint() error = %v, want %v", err, sysinfo.ErrNoFingerprint)
+	}
+
+	// Misspelled components are not silently left out.
+	_, err = si.Fingerprint(sysinfo.FingerprintProductUUID, "product.serialnumber")
+	if !errors.Is(err, sysinfo.ErrUnknownFingerprintComponent) {
+		t.Errorf("Fingerprint() error = %v, want %v", err, sysinfo.ErrUnknownFingerprintComponent)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"fmt"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// FirmwareComponent information, one for every firmware image the platform reports (BIOS, BMC, NIC, RAID...).
+type FirmwareComponent struct {
+	Name            string            `json:"name,omitempty"`
+	Version         string            `json:"version,omitempty"`
+	VersionFormat   string            `json:"versionformat,omitempty"`
+	ID              string            `json:"id,omitempty"`
+	IDFormat        string            `json:"idformat,omitempty"`
+	ReleaseDate     string            `json:"releasedate,omitempty"`
+	Vendor          string            `json:"vendor,omitempty"`
+	LowestVersion   string            `json:"lowestversion,omitempty"` // lowest version the firmware can be rolled back to
+	ImageSize       uint              `json:"imagesize,omitempty"`     // bytes
+	Characteristics []string          `json:"characteristics,omitempty"`
+	State           string            `json:"state,omitempty"`
+	Components      []string          `json:"components,omitempty"` // devices the firmware belongs to
+	Properties      map[string]string `json:"properties,omitempty"` // string properties, like UEFI device path
+}
+
+// Name of the device described by the structure, as printed on the motherboard, if it has one.
+func componentName(t *smbios.Table, handle uint16) string {
+	s := t.Handle(handle)
+	if s == nil {
+		return fmt.Sprintf("handle 0x%04x", handle)
+	}
+
+	var name string
+	switch s.Type {
+	case smbios.TypeProcessor, smbios.TypeSystemSlot, smbios.TypeOnboardDevice, smbios.TypeFirmwareInventory:
+		name = s.StringAt(0x04)
+	case smbios.TypeMemoryDevice:
+		name = s.StringAt(0x10)
+	}
+	if name == "" {
+		return fmt.Sprintf("type %d handle 0x%04x", s.Type, handle)
+	}
+
+	return name
+}
+
+func (si *SysInfo) getFirmwareInfo(p *probe) {
+	t := getSMBIOS(p)
+	if t == nil {
+		return
+	}
+
+	si.Firmware = nil
+	index := make(map[uint16]int)
+	for _, s := range t.Type(smbios.TypeFirmwareInventory) {
+		fi, err := s.FirmwareInventory()
+		if err != nil {
+			p.failSMBIOS(err)
+			continue
+		}
+
+		fc := FirmwareComponent{
+			Name:            fi.ComponentName,
+			Version:         fi.Version,
+			VersionFormat:   fi.VersionFormat.String(),
+			ID:              fi.ID,
+			ReleaseDate:     fi.ReleaseDate,
+			Vendor:          fi.Manufacturer,
+			LowestVersion:   fi.LowestVersion,
+			Characteristics: fi.Characteristics.Strings(),
+			State:           fi.State.String(),
+		}
+		if fi.ID != "" {
+			fc.IDFormat = fi.IDFormat.String()
+		}
+		if fi.ImageSize != ^uint64(0) {
+			fc.ImageSize = uint(fi.ImageSize)
+		}
+		for _, h := range fi.AssociatedComponents {
+			fc.Components = append(fc.Components, componentName(t, h))
+		}
+
+		index[fi.Handle] = len(si.Firmware)
+		si.Firmware = append(si.Firmware, fc)
+	}
+
+	// String properties extend firmware components with data that doesn't fit the fixed structure.
+	for _, s := range t.Type(smbios.TypeStringProperty) {
+		sp, err := s.StringProperty()
+		if err != nil {
+			p.failSMBIOS(err)
+			continue
+		}
+
+		i, ok := index[sp.ParentHandle]
+		if !ok {
+			continue
+		}
+		if si.Firmware[i].Properties == nil {
+			si.Firmware[i].Properties = make(map[string]string)
+		}
+		si.Firmware[i].Properties[sp.ID.String()] = sp.Value
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestFirmware(t *testing.T) {
+	// Devices the firmware belongs to: a slot and a memory module with names, an unnamed onboard device.
+	slot := structure(9, 0x0900, formatted(0x11, map[int]any{0x04: uint8(1)}), "PCIE1")
+	dimm := structure(17, 0x1100, formatted(0x28, map[int]any{0x10: uint8(1)}), "DIMM_A1")
+	onboard := structure(41, 0x2900, formatted(0x0b, map[int]any{0x05: uint8(0x85)}))
+
+	// NIC firmware, associated with the devices above, and a handle that doesn't exist.
+	firmware := structure(45, 0x2d00, formatted(0x20, map[int]any{
+		0x04: uint8(1), 0x05: uint8(2), 0x09: uint8(3), 0x0a: uint8(4), 0x0c: ^uint64(0), 0x14: uint16(1),
+		0x16: uint8(4), 0x17: uint8(4), 0x18: uint16(0x0900), 0x1a: uint16(0x1100), 0x1c: uint16(0x2900),
+		0x1e: uint16(0x7777),
+	}), "NIC FW", "22.31.6", "2023-01-01", "Intel")
+
+	// UEFI device path of the firmware, and a property of a structure that isn't firmware.
+	property := structure(46, 0x2e00, formatted(0x09, map[int]any{
+		0x04: uint16(1), 0x06: uint8(1), 0x07: uint16(0x2d00),
+	}), "PciRoot(0x0)/Pci(0x1,0x0)")
+	orphan := structure(46, 0x2e01, formatted(0x09, map[int]any{
+		0x04: uint16(1), 0x06: uint8(1), 0x07: uint16(0x0900),
+	}), "PciRoot(0x0)/Pci(0x3,0x0)")
+
+	root := fixture(t, map[string]string{
+		"sys/firmware/dmi/tables/DMI": slot + dimm + onboard + firmware + property + orphan + endOfTable,
+	})
+
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect(sysinfo.SectionFirmware)
+
+	want := []sysinfo.FirmwareComponent{{
+		Name:            "NIC FW",
+		Version:         "22.31.6",
+		VersionFormat:   "Free-form",
+		ReleaseDate:     "2023-01-01",
+		Vendor:          "Intel",
+		Characteristics: []string{"Updatable"},
+		State:           "Enabled",
+		Components:      []string{"PCIE1", "DIMM_A1", "type 41 handle 0x2900", "handle 0x7777"},
+		Properties:      map[string]string{"UEFI device path": "PciRoot(0x0)/Pci(0x1,0x0)"},
+	}}
+	if !reflect.DeepEqual(si.Firmware, want) {
+		t.Errorf("Firmware = %+v, want %+v", si.Firmware, want)
+	}
+}
@@ -5,6 +5,7 @@
 package sysinfo
 
 import (
+	"fmt"
 	"strings"
 	"unsafe"
 
@@ -21,21 +22,32 @@ var hvmap = map[string]string{
 	"XenVMMXenVMM": "xenhvm",
 }
 
-func isHypervisorActive() bool {
-	var info [4]uint32
-	cpuid.CPUID(&info, 0x1)
+// Execute CPUID, or look up its result in the replayed capture.
+func getCpuid(p *probe, ax uint32) (info [4]uint32) {
+	data, err := p.syscall(fmt.Sprintf("cpuid/%08x", ax), func() ([]byte, error) {
+		cpuid.CPUID(&info, ax)
+		return []byte(fmt.Sprintf("%08x %08x %08x %08x", info[0], info[1], info[2], info[3])), nil
+	})
+	if err == nil {
+		_, _ = fmt.Sscanf(string(data), "%x %x %x %x", &info[0], &info[1], &info[2], &info[3])
+	}
+
+	return
+}
+
+func isHypervisorActive(p *probe) bool {
+	info := getCpuid(p, 0x1)
 	return info[2]&(1<<31) != 0
 }
 
-func getHypervisorCpuid(ax uint32) string {
-	var info [4]uint32
-	cpuid.CPUID(&info, ax)
+func getHypervisorCpuid(p *probe, ax uint32) string {
+	info := getCpuid(p, ax)
 	return hvmap[strings.TrimRight(string((*[12]byte)(unsafe.Pointer(&info[1]))[:]), "\000")]
 }
 
-func (si *SysInfo) getHypervisor() {
-	if !isHypervisorActive() {
-		if hypervisorType := slurpFile("/sys/hypervisor/type"); hypervisorType != "" {
+func (si *SysInfo) getHypervisor(p *probe) {
+	if !isHypervisorActive(p) {
+		if hypervisorType := p.slurpOptional("/sys/hypervisor/type"); hypervisorType != "" {
 			if hypervisorType == "xen" {
 				si.Node.Hypervisor = "xenpv"
 			}
@@ -45,12 +57,12 @@ func (si *SysInfo) getHypervisor() {
 
 	// KVM has been caught to move its real signature to this leaf, and put something completely different in the
 	// standard location. So this leaf must be checked first.
-	if hv := getHypervisorCpuid(0x40000100); hv != "" {
+	if hv := getHypervisorCpuid(p, 0x40000100); hv != "" {
 		si.Node.Hypervisor = hv
 		return
 	}
 
-	if hv := getHypervisorCpuid(0x40000000); hv != "" {
+	if hv := getHypervisorCpuid(p, 0x40000000); hv != "" {
 		si.Node.Hypervisor = hv
 		return
 	}
@@ -10,5 +10,5 @@ type Kernel struct {
 	Architecture string `json:"architecture,omitempty"`
 }
 
-func (si *SysInfo) getKernelInfo() {
+func (si *SysInfo) getKernelInfo(p *probe) {
 }
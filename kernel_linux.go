@@ -20,14 +20,22 @@ type Kernel struct {
 	Architecture string `json:"architecture,omitempty"`
 }
 
-func (si *SysInfo) getKernelInfo() {
-	si.Kernel.Release = slurpFile("/proc/sys/kernel/osrelease")
-	si.Kernel.Version = slurpFile("/proc/sys/kernel/version")
+func (si *SysInfo) getKernelInfo(p *probe) {
+	si.Kernel.Release = p.slurpFile("/proc/sys/kernel/osrelease")
+	si.Kernel.Version = p.slurpFile("/proc/sys/kernel/version")
 
-	var uname syscall.Utsname
-	if err := syscall.Uname(&uname); err != nil {
+	machine, err := p.syscall("uname/machine", func() ([]byte, error) {
+		var uname syscall.Utsname
+		if err := syscall.Uname(&uname); err != nil {
+			return nil, err
+		}
+
+		return []byte(strings.TrimRight(string((*[65]byte)(unsafe.Pointer(&uname.Machine))[:]), "\000")), nil
+	})
+	if err != nil {
+		p.fail("uname", "", err)
 		return
 	}
 
-	si.Kernel.Architecture = strings.TrimRight(string((*[65]byte)(unsafe.Pointer(&uname.Machine))[:]), "\000")
+	si.Kernel.Architecture = string(machine)
 }
@@ -5,112 +5,182 @@
 package sysinfo
 
 import (
+	"bufio"
 	"bytes"
-	"encoding/binary"
-	"os"
+	"regexp"
 	"strconv"
+
+	"github.com/zcalusic/sysinfo/smbios"
 )
 
 // Memory information.
 type Memory struct {
-	Type  string `json:"type,omitempty"`
-	Speed uint   `json:"speed,omitempty"` // RAM data rate in MT/s
-	Size  uint   `json:"size,omitempty"`  // RAM size in MB
+	Type    string         `json:"type,omitempty"`
+	Speed   uint           `json:"speed,omitempty"` // RAM data rate in MT/s
+	Size    uint           `json:"size,omitempty"`  // RAM size in MB
+	Arrays  []MemoryArray  `json:"arrays,omitempty"`
+	Modules []MemoryModule `json:"modules,omitempty"`
 }
 
-func word(data []byte, index int) uint16 {
-	return binary.LittleEndian.Uint16(data[index : index+2])
+// MemoryArray information, a group of memory sockets (usually all of the motherboard) sharing error correction.
+type MemoryArray struct {
+	Handle          uint16 `json:"handle"` // SMBIOS handle, referenced by modules in the array
+	Location        string `json:"location,omitempty"`
+	Use             string `json:"use,omitempty"`
+	ErrorCorrection string `json:"errorcorrection,omitempty"`
+	MaxCapacity     uint   `json:"maxcapacity,omitempty"` // maximum RAM size the array supports in MB
+	Slots           uint   `json:"slots,omitempty"`
 }
 
-func dword(data []byte, index int) uint32 {
-	return binary.LittleEndian.Uint32(data[index : index+4])
+// MemoryModule information, one for every memory socket, including empty ones.
+type MemoryModule struct {
+	Array             uint16   `json:"array,omitempty"` // handle of the memory array the module belongs to
+	Locator           string   `json:"locator,omitempty"`
+	BankLocator       string   `json:"banklocator,omitempty"`
+	Empty             bool     `json:"empty,omitempty"` // no module installed in the socket
+	Size              uint     `json:"size,omitempty"`  // module size in MB
+	FormFactor        string   `json:"formfactor,omitempty"`
+	Type              string   `json:"type,omitempty"`
+	TypeDetail        []string `json:"typedetail,omitempty"`
+	Speed             uint     `json:"speed,omitempty"`           // rated data rate in MT/s
+	ConfiguredSpeed   uint     `json:"configuredspeed,omitempty"` // configured data rate in MT/s
+	Vendor            string   `json:"vendor,omitempty"`
+	Serial            string   `json:"serial,omitempty"`
+	AssetTag          string   `json:"assettag,omitempty"`
+	PartNumber        string   `json:"partnumber,omitempty"`
+	Rank              uint     `json:"rank,omitempty"`
+	DataWidth         uint     `json:"datawidth,omitempty"`         // bits
+	TotalWidth        uint     `json:"totalwidth,omitempty"`        // bits, including ECC
+	MinVoltage        uint     `json:"minvoltage,omitempty"`        // mV
+	MaxVoltage        uint     `json:"maxvoltage,omitempty"`        // mV
+	ConfiguredVoltage uint     `json:"configuredvoltage,omitempty"` // mV
 }
 
-func qword(data []byte, index int) uint64 {
-	return binary.LittleEndian.Uint64(data[index : index+8])
+func newMemoryModule(md *smbios.MemoryDevice) MemoryModule {
+	mm := MemoryModule{
+		Array:             md.ArrayHandle,
+		Locator:           md.DeviceLocator,
+		BankLocator:       md.BankLocator,
+		Empty:             md.Size == 0 && !md.SizeUnknown,
+		Size:              uint(md.Size >> 20),
+		Speed:             uint(md.Speed),
+		ConfiguredSpeed:   uint(md.ConfiguredSpeed),
+		Vendor:            md.Manufacturer,
+		Serial:            md.SerialNumber,
+		AssetTag:          md.AssetTag,
+		PartNumber:        md.PartNumber,
+		Rank:              uint(md.Rank),
+		DataWidth:         uint(md.DataWidth),
+		TotalWidth:        uint(md.TotalWidth),
+		MinVoltage:        uint(md.MinVoltage),
+		MaxVoltage:        uint(md.MaxVoltage),
+		ConfiguredVoltage: uint(md.ConfiguredVoltage),
+	}
+
+	if md.FormFactor != 0 {
+		mm.FormFactor = md.FormFactor.String()
+	}
+	if md.Type != 0 {
+		mm.Type = md.Type.String()
+		mm.TypeDetail = md.TypeDetail.Strings()
+	}
+
+	return mm
 }
 
-func (si *SysInfo) getMemoryInfo() {
-	dmi, err := os.ReadFile("/sys/firmware/dmi/tables/DMI")
+var reMemTotal = regexp.MustCompile(`^MemTotal:\s+(\d+) kB$`)
+
+func (si *SysInfo) getMemTotal(p *probe) {
+	meminfo, err := p.readFile("/proc/meminfo")
 	if err != nil {
+		p.failPath(err)
+		return
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(meminfo))
+	for s.Scan() {
+		if m := reMemTotal.FindStringSubmatch(s.Text()); m != nil {
+			if size, err := strconv.ParseUint(m[1], 10, 64); err == nil {
+				si.Memory.Size = uint(size) / 1024
+			} else {
+				p.failParse("/proc/meminfo", err)
+			}
+			return
+		}
+	}
+}
+
+func (si *SysInfo) getMemoryInfo(p *probe) {
+	t := getSMBIOS(p)
+	if t == nil {
 		// Xen hypervisor
-		if targetKB := slurpFile("/sys/devices/system/xen_memory/xen_memory0/target_kb"); targetKB != "" {
+		if targetKB := p.slurpOptional("/sys/devices/system/xen_memory/xen_memory0/target_kb"); targetKB != "" {
 			si.Memory.Type = "DRAM"
 			size, _ := strconv.ParseUint(targetKB, 10, 64)
 			si.Memory.Size = uint(size) / 1024
+			return
 		}
+
+		// Without privileges (or SMBIOS), RAM available to the kernel is the best estimate of RAM size.
+		si.getMemTotal(p)
 		return
 	}
 
 	si.Memory.Size = 0
+	si.Memory.Arrays = nil
+	si.Memory.Modules = nil
 	var memSizeAlt uint
-loop:
-	for p := 0; p < len(dmi)-1; {
-		recType := dmi[p]
-		recLen := dmi[p+1]
-
-		switch recType {
-		case 4:
-			if si.CPU.Speed == 0 {
-				si.CPU.Speed = uint(word(dmi, p+0x16))
-			}
-		case 17:
-			size := uint(word(dmi, p+0x0c))
-			if size == 0 || size == 0xffff || size&0x8000 == 0x8000 {
+	for _, s := range t.Structures {
+		switch s.Type {
+		case smbios.TypePhysicalMemoryArray:
+			a, err := s.PhysicalMemoryArray()
+			if err != nil {
+				p.failSMBIOS(err)
 				break
 			}
-			if size == 0x7fff {
-				if recLen >= 0x20 {
-					size = uint(dword(dmi, p+0x1c))
-				} else {
-					break
-				}
+
+			ma := MemoryArray{
+				Handle:      a.Handle,
+				MaxCapacity: uint(a.MaxCapacity >> 20),
+				Slots:       uint(a.NumDevices),
+			}
+			if a.Location != 0 {
+				ma.Location = a.Location.String()
+			}
+			if a.Use != 0 {
+				ma.Use = a.Use.String()
+			}
+			if a.ErrorCorrection != 0 {
+				ma.ErrorCorrection = a.ErrorCorrection.String()
 			}
 
-			si.Memory.Size += size
-
-			if si.Memory.Type == "" {
-				// SMBIOS Reference Specification Version 3.8.0, page 103
-				memTypes := [...]string{
-					"Other", "Unknown", "DRAM", "EDRAM", "VRAM", "SRAM", "RAM", "ROM", "FLASH",
-					"EEPROM", "FEPROM", "EPROM", "CDRAM", "3DRAM", "SDRAM", "SGRAM", "RDRAM",
-					"DDR", "DDR2", "DDR2 FB-DIMM", "Reserved", "Reserved", "Reserved", "DDR3",
-					"FBD2", "DDR4", "LPDDR", "LPDDR2", "LPDDR3", "LPDDR4", "Logical non-volatile device",
-					"HBM", "HBM2", "DDR5", "LPDDR5", "HBM3",
-				}
-
-				if index := int(dmi[p+0x12]); index >= 1 && index <= len(memTypes) {
-					si.Memory.Type = memTypes[index-1]
-				}
+			si.Memory.Arrays = append(si.Memory.Arrays, ma)
+		case smbios.TypeMemoryDevice:
+			md, err := s.MemoryDevice()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
 			}
 
-			if si.Memory.Speed == 0 && recLen >= 0x17 {
-				if speed := uint(word(dmi, p+0x15)); speed != 0 {
-					si.Memory.Speed = speed
-				}
+			si.Memory.Modules = append(si.Memory.Modules, newMemoryModule(md))
+
+			if md.Size == 0 {
+				break
 			}
-		case 19:
-			start := uint(dword(dmi, p+0x04))
-			end := uint(dword(dmi, p+0x08))
-			if start == 0xffffffff && end == 0xffffffff {
-				if recLen >= 0x1f {
-					start64 := qword(dmi, p+0x0f)
-					end64 := qword(dmi, p+0x17)
-					memSizeAlt += uint((end64 - start64 + 1) / 1048576)
-				}
-			} else {
-				memSizeAlt += (end - start + 1) / 1024
+
+			si.Memory.Size += uint(md.Size >> 20)
+
+			if si.Memory.Type == "" && md.Type != 0 {
+				si.Memory.Type = md.Type.String()
 			}
-		case 127:
-			break loop
-		}
 
-		for p += int(recLen); p < len(dmi)-1; {
-			if bytes.Equal(dmi[p:p+2], []byte{0, 0}) {
-				p += 2
-				break
+			if si.Memory.Speed == 0 {
+				si.Memory.Speed = uint(md.Speed)
+			}
+		case smbios.TypeMemoryArrayMappedAddress:
+			if m, err := s.MemoryArrayMappedAddress(); err == nil {
+				memSizeAlt += uint(m.Size() >> 20)
 			}
-			p++
 		}
 	}
 
@@ -0,0 +1,98 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestMemory(t *testing.T) {
+	// Motherboard array of two sockets, one populated with 8G DDR4 DIMM.
+	array := structure(16, 0x1000, formatted(0x0f, map[int]any{
+		0x04: uint8(0x03), 0x05: uint8(0x03), 0x06: uint8(0x03), 0x07: uint32(64 << 20), 0x0d: uint16(2),
+	}))
+	dimm := structure(17, 0x1100, formatted(0x28, map[int]any{
+		0x04: uint16(0x1000), 0x08: uint16(64), 0x0a: uint16(64), 0x0c: uint16(8192), 0x0e: uint8(0x09),
+		0x10: uint8(1), 0x11: uint8(2), 0x12: uint8(0x1a), 0x13: uint16(0x0080), 0x15: uint16(3200),
+	}), "DIMM_A1", "BANK 0")
+	empty := structure(17, 0x1101, formatted(0x28, map[int]any{
+		0x04: uint16(0x1000), 0x08: uint16(0xffff), 0x0a: uint16(0xffff), 0x10: uint8(1),
+	}), "DIMM_A2")
+
+	// Module of unknown size, and 4G mapped to the array.
+	unknown := structure(17, 0x1100, formatted(0x28, map[int]any{
+		0x04: uint16(0x1000), 0x0c: uint16(0xffff),
+	}))
+	mapped := structure(19, 0x1300, formatted(0x0f, map[int]any{
+		0x08: uint32(4<<20 - 1), 0x0c: uint16(0x1000), 0x0e: uint8(1),
+	}))
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  sysinfo.Memory
+	}{
+		{
+			name:  "modules",
+			files: map[string]string{"sys/firmware/dmi/tables/DMI": array + dimm + empty + endOfTable},
+			want: sysinfo.Memory{
+				Type:  "DDR4",
+				Speed: 3200,
+				Size:  8192,
+				Arrays: []sysinfo.MemoryArray{{
+					Handle:          0x1000,
+					Location:        "System board or motherboard",
+					Use:             "System memory",
+					ErrorCorrection: "None",
+					MaxCapacity:     65536,
+					Slots:           2,
+				}},
+				Modules: []sysinfo.MemoryModule{
+					{
+						Array: 0x1000, Locator: "DIMM_A1", BankLocator: "BANK 0", Size: 8192, FormFactor: "DIMM",
+						Type: "DDR4", TypeDetail: []string{"Synchronous"}, Speed: 3200, DataWidth: 64, TotalWidth: 64,
+					},
+					{Array: 0x1000, Locator: "DIMM_A2", Empty: true},
+				},
+			},
+		},
+		{
+			name:  "mapped address",
+			files: map[string]string{"sys/firmware/dmi/tables/DMI": array + unknown + mapped + endOfTable},
+			want: sysinfo.Memory{
+				Type: "DRAM",
+				Size: 4096,
+				Arrays: []sysinfo.MemoryArray{{
+					Handle:          0x1000,
+					Location:        "System board or motherboard",
+					Use:             "System memory",
+					ErrorCorrection: "None",
+					MaxCapacity:     65536,
+					Slots:           2,
+				}},
+				Modules: []sysinfo.MemoryModule{{Array: 0x1000}},
+			},
+		},
+		{
+			name:  "meminfo",
+			files: map[string]string{"proc/meminfo": "MemTotal:       16384000 kB\nMemFree:         8192000 kB"},
+			want:  sysinfo.Memory{Size: 16000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			si := sysinfo.SysInfo{Root: fixture(t, tt.files)}
+			_ = si.Collect(sysinfo.SectionMemory)
+
+			if !reflect.DeepEqual(si.Memory, tt.want) {
+				t.Errorf("Memory = %+v, want %+v", si.Memory, tt.want)
+			}
+		})
+	}
+}
@@ -8,11 +8,24 @@ import "time"
 
 // Meta information.
 type Meta struct {
-	Version   string    `json:"version"`
-	Timestamp time.Time `json:"timestamp"`
+	Version   string             `json:"version"`
+	Timestamp time.Time          `json:"timestamp"`
+	Sections  map[Section]Status `json:"sections,omitempty"` // gathered sections, and how completely
 }
 
+// Status of a gathered section.
+type Status string
+
+// Section statuses.
+const (
+	StatusComplete    Status = "complete"    // all available information was gathered
+	StatusPartial     Status = "partial"     // some information couldn't be gathered for lack of privileges
+	StatusIncomplete  Status = "incomplete"  // some information couldn't be read or parsed (see the returned errors)
+	StatusUnavailable Status = "unavailable" // no information could be gathered
+)
+
 func (si *SysInfo) getMetaInfo() {
 	si.Meta.Version = Version
 	si.Meta.Timestamp = time.Now()
+	si.Meta.Sections = make(map[Section]Status)
 }
@@ -5,8 +5,9 @@
 package sysinfo
 
 import (
-	"os"
+	"fmt"
 	"path"
+	"strconv"
 	"strings"
 	"syscall"
 	"unsafe"
@@ -14,11 +15,13 @@ import (
 
 // NetworkDevice information.
 type NetworkDevice struct {
-	Name       string `json:"name,omitempty"`
-	Driver     string `json:"driver,omitempty"`
-	MACAddress string `json:"macaddress,omitempty"`
-	Port       string `json:"port,omitempty"`
-	Speed      uint   `json:"speed,omitempty"` // device max supported speed in Mbps
+	Name         string `json:"name,omitempty"`
+	Driver       string `json:"driver,omitempty"`
+	MACAddress   string `json:"macaddress,omitempty"`
+	PermanentMAC string `json:"permanentmac,omitempty"` // burned-in MAC address, if the driver reports it
+	Port         string `json:"port,omitempty"`
+	Speed        uint   `json:"speed,omitempty"`   // device max supported speed in Mbps
+	Onboard      string `json:"onboard,omitempty"` // onboard device designation (see Onboard), empty for add-in cards
 }
 
 func getPortType(supp uint32) (port string) {
@@ -56,13 +59,63 @@ func getMaxSpeed(supp uint32) (speed uint) {
 	return
 }
 
-func getSupported(name string) uint32 {
-	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, syscall.IPPROTO_IP)
+func getSupported(p *probe, name string) uint32 {
+	data, err := p.syscall("ethtool/gset/"+name, func() ([]byte, error) {
+		supp, err := ethtoolSupported(name)
+		return []byte(strconv.FormatUint(uint64(supp), 10)), err
+	})
 	if err != nil {
+		p.fail("ethtool", name, err)
 		return 0
 	}
+
+	supp, _ := strconv.ParseUint(string(data), 10, 32)
+	return uint32(supp)
+}
+
+func getPermAddr(p *probe, name string) string {
+	data, err := p.syscall("ethtool/gpermaddr/"+name, func() ([]byte, error) {
+		addr, err := ethtoolPermAddr(name)
+		return []byte(addr), err
+	})
+	if err != nil {
+		p.fail("ethtool", name, err)
+		return ""
+	}
+
+	return string(data)
+}
+
+// Issue ethtool command on the named device, data points to ethtool command struct. Not all drivers implement
+// ethtool, or every ethtool command, that's not a failure, but leaves the struct untouched.
+func ethtoolIoctl(name string, data unsafe.Pointer) error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, syscall.IPPROTO_IP)
+	if err != nil {
+		return err
+	}
 	defer syscall.Close(fd)
 
+	// struct ifreq from /usr/include/linux/if.h
+	var ifr struct {
+		Name [16]byte
+		Data uintptr
+	}
+
+	copy(ifr.Name[:], name+"\000")
+	ifr.Data = uintptr(data)
+
+	// SIOCETHTOOL from /usr/include/linux/sockios.h
+	const SIOCETHTOOL = 0x8946
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), uintptr(SIOCETHTOOL), uintptr(unsafe.Pointer(&ifr)))
+	if errno != 0 && errno != syscall.EOPNOTSUPP {
+		return errno
+	}
+
+	return nil
+}
+
+func ethtoolSupported(name string) (uint32, error) {
 	// struct ethtool_cmd from /usr/include/linux/ethtool.h
 	var ethtool struct {
 		Cmd           uint32
@@ -89,38 +142,59 @@ func getSupported(name string) uint32 {
 
 	ethtool.Cmd = GSET
 
-	// struct ifreq from /usr/include/linux/if.h
-	var ifr struct {
-		Name [16]byte
-		Data uintptr
+	err := ethtoolIoctl(name, unsafe.Pointer(&ethtool))
+	return ethtool.Supported, err
+}
+
+func ethtoolPermAddr(name string) (string, error) {
+	// struct ethtool_perm_addr from /usr/include/linux/ethtool.h, with room for MAX_ADDR_LEN bytes of address
+	var ethtool struct {
+		Cmd  uint32
+		Size uint32
+		Data [32]byte
 	}
 
-	copy(ifr.Name[:], name+"\000")
-	ifr.Data = uintptr(unsafe.Pointer(&ethtool))
+	// ETHTOOL_GPERMADDR from /usr/include/linux/ethtool.h
+	const GPERMADDR = 0x20
 
-	// SIOCETHTOOL from /usr/include/linux/sockios.h
-	const SIOCETHTOOL = 0x8946
+	ethtool.Cmd = GPERMADDR
+	ethtool.Size = uint32(len(ethtool.Data))
 
-	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), uintptr(SIOCETHTOOL), uintptr(unsafe.Pointer(&ifr)))
-	if errno == 0 {
-		return ethtool.Supported
+	if err := ethtoolIoctl(name, unsafe.Pointer(&ethtool)); err != nil {
+		return "", err
+	}
+
+	if ethtool.Cmd != GPERMADDR || ethtool.Size > uint32(len(ethtool.Data)) {
+		return "", nil
+	}
+
+	var addr []string
+	nonzero := false
+	for _, b := range ethtool.Data[:ethtool.Size] {
+		addr = append(addr, fmt.Sprintf("%02x", b))
+		nonzero = nonzero || b != 0
+	}
+	if !nonzero {
+		return "", nil
 	}
 
-	return 0
+	return strings.Join(addr, ":"), nil
 }
 
-func (si *SysInfo) getNetworkInfo() {
+func (si *SysInfo) getNetworkInfo(p *probe) {
 	sysClassNet := "/sys/class/net"
-	devices, err := os.ReadDir(sysClassNet)
+	devices, err := p.readDir(sysClassNet)
 	if err != nil {
+		p.failPath(err)
 		return
 	}
 
 	si.Network = make([]NetworkDevice, 0)
 	for _, link := range devices {
 		fullpath := path.Join(sysClassNet, link.Name())
-		dev, err := os.Readlink(fullpath)
+		dev, err := p.readlink(fullpath)
 		if err != nil {
+			p.failPath(err)
 			continue
 		}
 
@@ -128,16 +202,17 @@ func (si *SysInfo) getNetworkInfo() {
 			continue
 		}
 
-		supp := getSupported(link.Name())
+		supp := getSupported(p, link.Name())
 
 		device := NetworkDevice{
-			Name:       link.Name(),
-			MACAddress: slurpFile(path.Join(fullpath, "address")),
-			Port:       getPortType(supp),
-			Speed:      getMaxSpeed(supp),
+			Name:         link.Name(),
+			MACAddress:   p.slurpFile(path.Join(fullpath, "address")),
+			PermanentMAC: getPermAddr(p, link.Name()),
+			Port:         getPortType(supp),
+			Speed:        getMaxSpeed(supp),
 		}
 
-		if driver, err := os.Readlink(path.Join(fullpath, "device", "driver")); err == nil {
+		if driver, err := p.readlink(path.Join(fullpath, "device", "driver")); err == nil {
 			device.Driver = path.Base(driver)
 		}
 
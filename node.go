@@ -6,63 +6,72 @@ package sysinfo
 
 import (
 	"bufio"
+	"bytes"
 	"os"
 	"strings"
 )
 
 // Node information.
 type Node struct {
-	Hostname   string `json:"hostname,omitempty"`
-	MachineID  string `json:"machineid,omitempty"`
-	Hypervisor string `json:"hypervisor,omitempty"`
-	Timezone   string `json:"timezone,omitempty"`
+	Hostname          string             `json:"hostname,omitempty"`
+	MachineID         string             `json:"machineid,omitempty"`
+	MachineIDMismatch *MachineIDMismatch `json:"machineidmismatch,omitempty"`
+	Hypervisor        string             `json:"hypervisor,omitempty"`
+	Timezone          string             `json:"timezone,omitempty"`
 }
 
-func (si *SysInfo) getHostname() {
-	si.Node.Hostname = slurpFile("/proc/sys/kernel/hostname")
+// MachineIDMismatch information, reported when systemd and D-Bus machine IDs differ, or one of them is missing.
+type MachineIDMismatch struct {
+	Systemd  string `json:"systemd,omitempty"`  // content of /etc/machine-id
+	DBus     string `json:"dbus,omitempty"`     // content of /var/lib/dbus/machine-id
+	Repaired bool   `json:"repaired,omitempty"` // whether the other file was overwritten to match
 }
 
-func (si *SysInfo) getSetMachineID() {
+func (si *SysInfo) getHostname(p *probe) {
+	si.Node.Hostname = p.slurpFile("/proc/sys/kernel/hostname")
+}
+
+func (si *SysInfo) getSetMachineID(p *probe) {
 	const pathSystemdMachineID = "/etc/machine-id"
 	const pathDbusMachineID = "/var/lib/dbus/machine-id"
 
-	systemdMachineID := slurpFile(pathSystemdMachineID)
-	dbusMachineID := slurpFile(pathDbusMachineID)
+	systemdMachineID := p.slurpOptional(pathSystemdMachineID)
+	dbusMachineID := p.slurpOptional(pathDbusMachineID)
 
-	if systemdMachineID != "" && dbusMachineID != "" {
-		// All OK, just return the machine id.
-		if systemdMachineID == dbusMachineID {
-			si.Node.MachineID = systemdMachineID
-			return
-		}
-
-		// They both exist, but they don't match! Copy systemd machine id to DBUS machine id.
-		spewFile(pathDbusMachineID, systemdMachineID, 0444)
+	// All OK (or nothing to work with), just return the machine id.
+	if systemdMachineID == dbusMachineID {
 		si.Node.MachineID = systemdMachineID
 		return
 	}
 
+	mismatch := &MachineIDMismatch{
+		Systemd: systemdMachineID,
+		DBus:    dbusMachineID,
+	}
+	si.Node.MachineIDMismatch = mismatch
+
 	// Copy DBUS machine id to non-existent systemd machine id.
-	if systemdMachineID == "" && dbusMachineID != "" {
-		spewFile(pathSystemdMachineID, dbusMachineID, 0444)
+	if systemdMachineID == "" {
+		if !si.ReadOnly {
+			mismatch.Repaired = p.spewFile(pathSystemdMachineID, dbusMachineID, 0444)
+		}
 		si.Node.MachineID = dbusMachineID
 		return
 	}
 
-	// Copy systemd machine id to non-existent DBUS machine id.
-	if systemdMachineID != "" && dbusMachineID == "" {
-		spewFile(pathDbusMachineID, systemdMachineID, 0444)
-		si.Node.MachineID = systemdMachineID
-		return
+	// Copy systemd machine id to DBUS machine id, either they don't match, or DBUS machine id doesn't exist.
+	if !si.ReadOnly {
+		mismatch.Repaired = p.spewFile(pathDbusMachineID, systemdMachineID, 0444)
 	}
+	si.Node.MachineID = systemdMachineID
 }
 
-func (si *SysInfo) getTimezone() {
+func (si *SysInfo) getTimezone(p *probe) {
 	const zoneInfoPrefix = "/usr/share/zoneinfo/"
 
-	if fi, err := os.Lstat("/etc/localtime"); err == nil {
+	if fi, err := p.lstat("/etc/localtime"); err == nil {
 		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
-			if tzfile, err := os.Readlink("/etc/localtime"); err == nil {
+			if tzfile, err := p.readlink("/etc/localtime"); err == nil {
 				tzfile = strings.TrimPrefix(tzfile, "..")
 				if strings.HasPrefix(tzfile, zoneInfoPrefix) {
 					si.Node.Timezone = strings.TrimPrefix(tzfile, zoneInfoPrefix)
@@ -72,14 +81,13 @@ func (si *SysInfo) getTimezone() {
 		}
 	}
 
-	if timezone := slurpFile("/etc/timezone"); timezone != "" {
+	if timezone := p.slurpOptional("/etc/timezone"); timezone != "" {
 		si.Node.Timezone = timezone
 		return
 	}
 
-	if f, err := os.Open("/etc/sysconfig/clock"); err == nil {
-		defer f.Close()
-		s := bufio.NewScanner(f)
+	if clock, err := p.readFile("/etc/sysconfig/clock"); err == nil {
+		s := bufio.NewScanner(bytes.NewReader(clock))
 		for s.Scan() {
 			if sl := strings.Split(s.Text(), "="); len(sl) == 2 {
 				if sl[0] == "ZONE" {
@@ -91,9 +99,9 @@ func (si *SysInfo) getTimezone() {
 	}
 }
 
-func (si *SysInfo) getNodeInfo() {
-	si.getHostname()
-	si.getSetMachineID()
-	si.getHypervisor()
-	si.getTimezone()
+func (si *SysInfo) getNodeInfo(p *probe) {
+	si.getHostname(p)
+	si.getSetMachineID(p)
+	si.getHypervisor(p)
+	si.getTimezone(p)
 }
@@ -0,0 +1,43 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestMachineIDMismatch(t *testing.T) {
+	const systemd, dbus = "0123456789abcdef0123456789abcdef", "fedcba9876543210fedcba9876543210"
+
+	// Root implies read-only, machine IDs of another system are never repaired.
+	for _, readOnly := range []bool{true, false} {
+		root := fixture(t, map[string]string{
+			"etc/machine-id":          systemd,
+			"var/lib/dbus/machine-id": dbus,
+		})
+
+		si := sysinfo.SysInfo{Root: root, ReadOnly: readOnly}
+		_ = si.Collect(sysinfo.SectionNode)
+
+		if si.Node.MachineID != systemd {
+			t.Errorf("ReadOnly=%v: MachineID = %q, want %q", readOnly, si.Node.MachineID, systemd)
+		}
+		if m := si.Node.MachineIDMismatch; m == nil || m.Systemd != systemd || m.DBus != dbus || m.Repaired {
+			t.Errorf("ReadOnly=%v: MachineIDMismatch = %+v", readOnly, m)
+		}
+
+		data, err := os.ReadFile(filepath.Join(root, "var/lib/dbus/machine-id"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != dbus+"\n" {
+			t.Errorf("ReadOnly=%v: D-Bus machine ID overwritten with %q", readOnly, data)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// Onboard information, devices and port connectors built into the motherboard.
+type Onboard struct {
+	Devices []OnboardDevice `json:"devices,omitempty"`
+	Ports   []Port          `json:"ports,omitempty"`
+}
+
+// OnboardDevice information.
+type OnboardDevice struct {
+	Designation string `json:"designation,omitempty"` // like "Embedded NIC 1"
+	Type        string `json:"type,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Address     string `json:"address,omitempty"` // PCI address
+}
+
+// Port connector information.
+type Port struct {
+	Internal          string `json:"internal,omitempty"` // internal reference designator, like "J1A1"
+	InternalConnector string `json:"internalconnector,omitempty"`
+	External          string `json:"external,omitempty"` // external reference designator, like "Rear USB 1"
+	ExternalConnector string `json:"externalconnector,omitempty"`
+	Type              string `json:"type,omitempty"`
+}
+
+// Find the onboard device at the PCI address the sysfs device (or its parent) link points to.
+func (o *Onboard) find(link string) string {
+	for _, dir := range slices.Backward(strings.Split(link, "/")) {
+		if !rePCIAddress.MatchString(dir) {
+			continue
+		}
+
+		for _, d := range o.Devices {
+			if d.Address == dir {
+				return d.Designation
+			}
+		}
+		break
+	}
+
+	return ""
+}
+
+// Link onboard network and storage devices, to tell LOM (LAN on motherboard) ports from add-in card ports.
+func (si *SysInfo) linkOnboard(p *probe) {
+	// Network and storage devices are shared with the caller, so modify only private copies.
+	si.Network = slices.Clone(si.Network)
+	for i := range si.Network {
+		if link, err := p.readlink(path.Join("/sys/class/net", si.Network[i].Name, "device")); err == nil {
+			si.Network[i].Onboard = si.Onboard.find(link)
+		}
+	}
+
+	si.Storage = slices.Clone(si.Storage)
+	for i := range si.Storage {
+		if link, err := p.readlink(path.Join("/sys/block", si.Storage[i].Name)); err == nil {
+			si.Storage[i].Onboard = si.Onboard.find(link)
+		}
+	}
+}
+
+func (si *SysInfo) getOnboardInfo(p *probe) {
+	t := getSMBIOS(p)
+	if t == nil {
+		return
+	}
+
+	si.Onboard = Onboard{}
+	for _, s := range t.Structures {
+		switch s.Type {
+		case smbios.TypeOnboardDevice:
+			d, err := s.OnboardDevice()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
+			}
+
+			si.Onboard.Devices = append(si.Onboard.Devices, OnboardDevice{
+				Designation: d.Designation,
+				Type:        d.Type.String(),
+				Enabled:     d.Enabled,
+				Address:     d.PCIAddress(),
+			})
+		case smbios.TypePortConnector:
+			pc, err := s.PortConnector()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
+			}
+
+			port := Port{
+				Internal: pc.InternalDesignator,
+				External: pc.ExternalDesignator,
+				Type:     pc.PortType.String(),
+			}
+			if pc.InternalConnectorType != 0 {
+				port.InternalConnector = pc.InternalConnectorType.String()
+			}
+			if pc.ExternalConnectorType != 0 {
+				port.ExternalConnector = pc.ExternalConnectorType.String()
+			}
+
+			si.Onboard.Ports = append(si.Onboard.Ports, port)
+		}
+	}
+
+	si.linkOnboard(p)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestOnboardNetwork(t *testing.T) {
+	// Enabled embedded Ethernet controller at 0000:19:00.0.
+	lom := structure(41, 0x2900, formatted(0x0b, map[int]any{
+		0x04: uint8(1), 0x05: uint8(0x80 | 0x05), 0x06: uint8(1), 0x09: uint8(0x19),
+	}), "Embedded NIC 1")
+
+	// LOM port, and add-in card port at an address SMBIOS doesn't know about.
+	devices := map[string]string{
+		"eno1":   "pci0000:17/0000:17:00.0/0000:19:00.0",
+		"ens1f0": "pci0000:3a/0000:3a:00.0/0000:3b:00.0",
+	}
+
+	files := map[string]string{"sys/firmware/dmi/tables/DMI": lom + endOfTable}
+	for name, dev := range devices {
+		files[filepath.Join("sys/devices", dev, "net", name, "address")] = "00:11:22:33:44:55"
+	}
+	root := fixture(t, files)
+	for name, dev := range devices {
+		links := map[string]string{
+			filepath.Join("sys/class/net", name):                     filepath.Join("../../devices", dev, "net", name),
+			filepath.Join("sys/devices", dev, "net", name, "device"): "../../../" + filepath.Base(dev),
+		}
+		for link, target := range links {
+			if err := os.MkdirAll(filepath.Join(root, filepath.Dir(link)), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Symlink(target, filepath.Join(root, link)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect(sysinfo.SectionOnboard)
+
+	want := map[string]string{"eno1": "Embedded NIC 1", "ens1f0": ""}
+	if len(si.Network) != len(want) {
+		t.Fatalf("Network = %+v, want %d devices", si.Network, len(want))
+	}
+	for _, d := range si.Network {
+		if d.Onboard != want[d.Name] {
+			t.Errorf("%s Onboard = %q, want %q", d.Name, d.Onboard, want[d.Name])
+		}
+	}
+}
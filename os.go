@@ -6,7 +6,7 @@ package sysinfo
 
 import (
 	"bufio"
-	"os"
+	"bytes"
 	"regexp"
 	"strings"
 )
@@ -31,21 +31,21 @@ var (
 	reRedHat     = regexp.MustCompile(`[\( ]([\d\.]+)`)
 )
 
-func (si *SysInfo) getOSInfo() {
+func (si *SysInfo) getOSInfo(p *probe) {
 	// This seems to be the best and most portable way to detect OS architecture (NOT kernel!)
-	if _, err := os.Stat("/lib64/ld-linux-x86-64.so.2"); err == nil {
+	if _, err := p.stat("/lib64/ld-linux-x86-64.so.2"); err == nil {
 		si.OS.Architecture = "amd64"
-	} else if _, err := os.Stat("/lib/ld-linux.so.2"); err == nil {
+	} else if _, err := p.stat("/lib/ld-linux.so.2"); err == nil {
 		si.OS.Architecture = "i386"
 	}
 
-	f, err := os.Open("/etc/os-release")
+	osRelease, err := p.readFile("/etc/os-release")
 	if err != nil {
+		p.failPath(err)
 		return
 	}
-	defer f.Close()
 
-	s := bufio.NewScanner(f)
+	s := bufio.NewScanner(bytes.NewReader(osRelease))
 	for s.Scan() {
 		if m := rePrettyName.FindStringSubmatch(s.Text()); m != nil {
 			si.OS.Name = strings.Trim(m[1], `"`)
@@ -55,16 +55,19 @@ func (si *SysInfo) getOSInfo() {
 			si.OS.Version = strings.Trim(m[1], `"`)
 		}
 	}
+	if err := s.Err(); err != nil {
+		p.failPath(err)
+	}
 
 	switch si.OS.Vendor {
 	case "debian":
-		si.OS.Release = slurpFile("/etc/debian_version")
+		si.OS.Release = p.slurpFile("/etc/debian_version")
 	case "ubuntu":
 		if m := reUbuntu.FindStringSubmatch(si.OS.Name); m != nil {
 			si.OS.Release = m[1]
 		}
 	case "almalinux":
-		if release := slurpFile("/etc/almalinux-release"); release != "" {
+		if release := p.slurpFile("/etc/almalinux-release"); release != "" {
 			if m := reAlma.FindStringSubmatch(release); m != nil {
 				si.OS.Release = m[1]
 			}
@@ -72,13 +75,13 @@ func (si *SysInfo) getOSInfo() {
 
 		si.OS.Version = strings.Split(si.OS.Release, ".")[0]
 	case "centos":
-		if release := slurpFile("/etc/centos-release"); release != "" {
+		if release := p.slurpFile("/etc/centos-release"); release != "" {
 			if m := reCentOS.FindStringSubmatch(release); m != nil {
 				si.OS.Release = m[2]
 			}
 		}
 	case "rocky":
-		if release := slurpFile("/etc/rocky-release"); release != "" {
+		if release := p.slurpFile("/etc/rocky-release"); release != "" {
 			if m := reRocky.FindStringSubmatch(release); m != nil {
 				si.OS.Release = m[1]
 			}
@@ -87,7 +90,7 @@ func (si *SysInfo) getOSInfo() {
 		si.OS.Version = strings.Split(si.OS.Release, ".")[0]
 
 	case "rhel":
-		if release := slurpFile("/etc/redhat-release"); release != "" {
+		if release := p.slurpFile("/etc/redhat-release"); release != "" {
 			if m := reRedHat.FindStringSubmatch(release); m != nil {
 				si.OS.Release = m[1]
 			}
@@ -0,0 +1,194 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// Power information, power supplies of servers, and batteries of portable computers.
+type Power struct {
+	Supplies  []PowerSupply `json:"supplies,omitempty"`
+	Batteries []Battery     `json:"batteries,omitempty"`
+}
+
+// PowerSupply information.
+type PowerSupply struct {
+	Name           string `json:"name,omitempty"`
+	Location       string `json:"location,omitempty"`
+	Vendor         string `json:"vendor,omitempty"`
+	Model          string `json:"model,omitempty"`
+	Revision       string `json:"revision,omitempty"`
+	Serial         string `json:"serial,omitempty"`
+	AssetTag       string `json:"assettag,omitempty"`
+	MaxPower       uint   `json:"maxpower,omitempty"` // max power capacity in W
+	Type           string `json:"type,omitempty"`
+	Status         string `json:"status,omitempty"`
+	RangeSwitching string `json:"rangeswitching,omitempty"` // input voltage range switching
+	Group          uint   `json:"group,omitempty"`          // redundant power supplies share the group
+	Present        bool   `json:"present"`
+	HotReplaceable bool   `json:"hotreplaceable,omitempty"`
+	Unplugged      bool   `json:"unplugged,omitempty"`
+}
+
+// Battery information.
+type Battery struct {
+	Name            string `json:"name,omitempty"`
+	Location        string `json:"location,omitempty"`
+	Vendor          string `json:"vendor,omitempty"`
+	Model           string `json:"model,omitempty"`
+	Serial          string `json:"serial,omitempty"`
+	ManufactureDate string `json:"manufacturedate,omitempty"`
+	Technology      string `json:"technology,omitempty"`     // battery chemistry, like "Li-ion"
+	DesignCapacity  uint   `json:"designcapacity,omitempty"` // mWh
+	DesignVoltage   uint   `json:"designvoltage,omitempty"`  // mV
+}
+
+func (si *SysInfo) getPowerSMBIOS(p *probe) {
+	t := getSMBIOS(p)
+	if t == nil {
+		return
+	}
+
+	for _, s := range t.Structures {
+		switch s.Type {
+		case smbios.TypePowerSupply:
+			ps, err := s.PowerSupply()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
+			}
+
+			si.Power.Supplies = append(si.Power.Supplies, PowerSupply{
+				Name:           ps.DeviceName,
+				Location:       ps.Location,
+				Vendor:         ps.Manufacturer,
+				Model:          ps.ModelPartNumber,
+				Revision:       ps.RevisionLevel,
+				Serial:         ps.SerialNumber,
+				AssetTag:       ps.AssetTag,
+				MaxPower:       uint(ps.MaxPowerCapacity),
+				Type:           ps.Characteristics.Type(),
+				Status:         ps.Characteristics.Status(),
+				RangeSwitching: ps.Characteristics.RangeSwitching(),
+				Group:          uint(ps.Group),
+				Present:        ps.Characteristics.Present(),
+				HotReplaceable: ps.Characteristics.HotReplaceable(),
+				Unplugged:      ps.Characteristics.Unplugged(),
+			})
+		case smbios.TypePortableBattery:
+			b, err := s.PortableBattery()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
+			}
+
+			battery := Battery{
+				Name:            b.DeviceName,
+				Location:        b.Location,
+				Vendor:          b.Manufacturer,
+				Serial:          b.SerialNumber,
+				ManufactureDate: b.ManufactureDate,
+				Technology:      b.SBDSChemistry,
+				DesignCapacity:  uint(b.DesignCapacity),
+				DesignVoltage:   uint(b.DesignVoltage),
+			}
+			if battery.Technology == "" && b.Chemistry != 0 {
+				battery.Technology = b.Chemistry.String()
+			}
+
+			si.Power.Batteries = append(si.Power.Batteries, battery)
+		}
+	}
+}
+
+// Find the battery from SMBIOS that the sysfs battery describes, matched by serial number, or the only battery there is
+// if it has no serial number. Batteries already merged with another sysfs battery are skipped.
+func (pw *Power) findBattery(serial string, merged map[int]bool) int {
+	for i := range pw.Batteries {
+		if serial != "" && pw.Batteries[i].Serial == serial && !merged[i] {
+			return i
+		}
+	}
+
+	if len(pw.Batteries) == 1 && pw.Batteries[0].Serial == "" && !merged[0] {
+		return 0
+	}
+
+	return -1
+}
+
+// Replace the string, unless the replacement is empty.
+func replaceNonEmpty(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+func (si *SysInfo) getPowerInfo(p *probe) {
+	si.Power = Power{}
+	si.getPowerSMBIOS(p)
+
+	sysClassPower := "/sys/class/power_supply"
+	supplies, err := p.readDir(sysClassPower)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			p.failPath(err)
+		}
+		return
+	}
+
+	merged := make(map[int]bool)
+	for _, supply := range supplies {
+		fullpath := path.Join(sysClassPower, supply.Name())
+		vendor := p.slurpOptional(path.Join(fullpath, "manufacturer"))
+		model := p.slurpOptional(path.Join(fullpath, "model_name"))
+		serial := p.slurpOptional(path.Join(fullpath, "serial_number"))
+
+		switch p.slurpOptional(path.Join(fullpath, "type")) {
+		case "Battery":
+			i := si.Power.findBattery(serial, merged)
+			if i < 0 {
+				i = len(si.Power.Batteries)
+				si.Power.Batteries = append(si.Power.Batteries, Battery{Name: supply.Name()})
+			}
+			merged[i] = true
+			b := &si.Power.Batteries[i]
+
+			replaceNonEmpty(&b.Vendor, vendor)
+			replaceNonEmpty(&b.Model, model)
+			replaceNonEmpty(&b.Serial, serial)
+			replaceNonEmpty(&b.Technology, p.slurpOptional(path.Join(fullpath, "technology")))
+
+			// Energy in µWh, or charge in µAh at minimum voltage in µV.
+			voltage := p.slurpUint(path.Join(fullpath, "voltage_min_design"))
+			if energy := p.slurpUint(path.Join(fullpath, "energy_full_design")); energy > 0 {
+				b.DesignCapacity = energy / 1000
+			} else if charge := p.slurpUint(path.Join(fullpath, "charge_full_design")); charge > 0 && voltage > 0 {
+				b.DesignCapacity = uint(uint64(charge) * uint64(voltage) / 1e9)
+			}
+			if voltage > 0 {
+				b.DesignVoltage = voltage / 1000
+			}
+		case "Mains", "UPS":
+			// AC adapters have no inventory information, only power supplies that do (like UPS) are interesting.
+			if vendor == "" && model == "" && serial == "" {
+				continue
+			}
+
+			si.Power.Supplies = append(si.Power.Supplies, PowerSupply{
+				Name:    supply.Name(),
+				Vendor:  vendor,
+				Model:   model,
+				Serial:  serial,
+				Present: true,
+			})
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestPowerSysfs(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/power_supply/AC/type":                 "Mains",
+		"sys/class/power_supply/AC/online":               "1",
+		"sys/class/power_supply/BAT0/type":               "Battery",
+		"sys/class/power_supply/BAT0/manufacturer":       "SMP",
+		"sys/class/power_supply/BAT0/model_name":         "5B10W13975",
+		"sys/class/power_supply/BAT0/serial_number":      "1234",
+		"sys/class/power_supply/BAT0/technology":         "Li-poly",
+		"sys/class/power_supply/BAT0/charge_full_design": "3000000",
+		"sys/class/power_supply/BAT0/voltage_min_design": "15400000",
+		"sys/class/power_supply/ups/type":                "UPS",
+		"sys/class/power_supply/ups/manufacturer":        "APC",
+		"sys/class/power_supply/ups/model_name":          "Back-UPS ES 700",
+		"sys/class/power_supply/BAT1/type":               "Battery",
+		"sys/class/power_supply/BAT1/energy_full_design": "57000000",
+		"sys/class/power_supply/BAT1/technology":         "Li-ion",
+	})
+
+	si := sysinfo.SysInfo{Root: root}
+	// There's no SMBIOS table in root, reported as a (normal) missing file.
+	if err := si.GetSysInfoContext(context.Background(), sysinfo.SectionPower); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal(err)
+	}
+
+	want := sysinfo.Power{
+		Supplies: []sysinfo.PowerSupply{
+			{Name: "ups", Vendor: "APC", Model: "Back-UPS ES 700", Present: true},
+		},
+		Batteries: []sysinfo.Battery{
+			{Name: "BAT0", Vendor: "SMP", Model: "5B10W13975", Serial: "1234", Technology: "Li-poly",
+				DesignCapacity: 46200, DesignVoltage: 15400},
+			{Name: "BAT1", Technology: "Li-ion", DesignCapacity: 57000},
+		},
+	}
+	if !reflect.DeepEqual(si.Power, want) {
+		t.Errorf("Power = %+v, want %+v", si.Power, want)
+	}
+}
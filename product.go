@@ -4,7 +4,12 @@
 
 package sysinfo
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/zcalusic/sysinfo/smbios"
+)
 
 // Product information.
 type Product struct {
@@ -14,18 +19,42 @@ type Product struct {
 	Serial  string    `json:"serial,omitempty"`
 	UUID    uuid.UUID `json:"uuid,omitempty"`
 	SKU     string    `json:"sku,omitempty"`
+	Family  string    `json:"family,omitempty"`
+	WakeUp  string    `json:"wakeup,omitempty"` // event that powered the system up, like "Power Switch"
+
+	// Free form strings set by the vendor, or injected by the hypervisor (like QEMU -smbios type=11).
+	OEMStrings    []string `json:"oemstrings,omitempty"`
+	ConfigOptions []string `json:"configoptions,omitempty"` // system configuration options (jumper settings)
 }
 
-func (si *SysInfo) getProductInfo() {
-	si.Product.Name = slurpFile("/sys/class/dmi/id/product_name")
-	si.Product.Vendor = slurpFile("/sys/class/dmi/id/sys_vendor")
-	si.Product.Version = slurpFile("/sys/class/dmi/id/product_version")
-	si.Product.Serial = slurpFile("/sys/class/dmi/id/product_serial")
-	si.Product.SKU = slurpFile("/sys/class/dmi/id/product_sku")
+// MarshalJSON leaves out zero UUID (not gathered, or not set by the vendor), omitempty doesn't apply to arrays.
+func (p Product) MarshalJSON() ([]byte, error) {
+	type product Product
+	v := struct {
+		product
+		UUID *uuid.UUID `json:"uuid,omitempty"`
+	}{product: product(p)}
+	if p.UUID != uuid.Nil {
+		v.UUID = &p.UUID
+	}
 
-	uid, err := uuid.Parse(slurpFile("/sys/class/dmi/id/product_uuid"))
-	if err == nil {
-		si.Product.UUID = uid
+	return json.Marshal(v)
+}
+
+func (si *SysInfo) getProductInfo(p *probe) {
+	si.Product.Name = p.slurpFile("/sys/class/dmi/id/product_name")
+	si.Product.Vendor = p.slurpFile("/sys/class/dmi/id/sys_vendor")
+	si.Product.Version = p.slurpFile("/sys/class/dmi/id/product_version")
+	si.Product.Serial = p.slurpFile("/sys/class/dmi/id/product_serial")
+	si.Product.SKU = p.slurpOptional("/sys/class/dmi/id/product_sku")
+	si.Product.Family = p.slurpOptional("/sys/class/dmi/id/product_family")
+
+	if uid := p.slurpFile("/sys/class/dmi/id/product_uuid"); uid != "" {
+		if u, err := uuid.Parse(uid); err == nil {
+			si.Product.UUID = u
+		} else {
+			p.failParse("/sys/class/dmi/id/product_uuid", err)
+		}
 	}
 
 	// try a fallback to device-tree (ex: dmi is not available on ARM devices)
@@ -33,10 +62,53 @@ func (si *SysInfo) getProductInfo() {
 
 	// on linux root path is /proc/device-tree (see: https://github.com/torvalds/linux/blob/v5.9/Documentation/ABI/testing/sysfs-firmware-ofw)
 	if si.Product.Name == "" {
-		si.Product.Name = slurpFile("/proc/device-tree/model")
+		si.Product.Name = p.slurpOptional("/proc/device-tree/model")
 	}
 
 	if si.Product.Serial == "" {
-		si.Product.Serial = slurpFile("/proc/device-tree/serial-number")
+		si.Product.Serial = p.slurpOptional("/proc/device-tree/serial-number")
+	}
+
+	// Wake-up type, OEM strings and configuration options are extras, the section is complete without them, even if
+	// the table can't be read.
+	t := p.dmi.read(p)
+	if t == nil {
+		return
+	}
+
+	si.Product.OEMStrings = nil
+	si.Product.ConfigOptions = nil
+	for _, s := range t.Structures {
+		switch s.Type {
+		case smbios.TypeSystem:
+			sys, err := s.System()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
+			}
+
+			if sys.WakeUpType != 0 {
+				si.Product.WakeUp = sys.WakeUpType.String()
+			}
+			if si.Product.Family == "" {
+				si.Product.Family = sys.Family
+			}
+		case smbios.TypeOEMStrings:
+			oem, err := s.OEMStrings()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
+			}
+
+			si.Product.OEMStrings = append(si.Product.OEMStrings, oem...)
+		case smbios.TypeConfigurationOptions:
+			options, err := s.ConfigurationOptions()
+			if err != nil {
+				p.failSMBIOS(err)
+				break
+			}
+
+			si.Product.ConfigOptions = append(si.Product.ConfigOptions, options...)
+		}
 	}
 }
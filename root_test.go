@@ -0,0 +1,48 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestRootSymlinks(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"etc/hostname":       "root",
+		"usr/lib/os-release": `PRETTY_NAME="Root Linux"`,
+		"lib/x86_64-linux-gnu/ld-linux-x86-64.so.2": "",
+		"usr/share/zoneinfo/Europe/Zagreb":          "",
+	})
+
+	// Absolute links point to files under root, not on the host.
+	links := map[string]string{
+		"etc/os-release":           "/usr/lib/os-release",
+		"lib64":                    "/lib/x86_64-linux-gnu/..",
+		"lib/ld-linux-x86-64.so.2": "/lib/x86_64-linux-gnu/ld-linux-x86-64.so.2",
+		"etc/localtime":            "../usr/share/zoneinfo/Europe/Zagreb",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect(sysinfo.SectionOS, sysinfo.SectionNode)
+
+	if si.OS.Name != "Root Linux" {
+		t.Errorf("OS.Name = %q, want %q", si.OS.Name, "Root Linux")
+	}
+	if si.OS.Architecture != "amd64" {
+		t.Errorf("OS.Architecture = %q, want %q", si.OS.Architecture, "amd64")
+	}
+	if si.Node.Timezone != "Europe/Zagreb" {
+		t.Errorf("Node.Timezone = %q, want %q", si.Node.Timezone, "Europe/Zagreb")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+)
+
+// Section of system information, named the same as in JSON output.
+type Section string
+
+// Sections that can be gathered selectively.
+const (
+	SectionNode     Section = "node"
+	SectionOS       Section = "os"
+	SectionKernel   Section = "kernel"
+	SectionProduct  Section = "product"
+	SectionBoard    Section = "board"
+	SectionChassis  Section = "chassis"
+	SectionBIOS     Section = "bios"
+	SectionCPU      Section = "cpu"
+	SectionMemory   Section = "memory"
+	SectionStorage  Section = "storage"
+	SectionNetwork  Section = "network"
+	SectionSlots    Section = "slots"
+	SectionPower    Section = "power"
+	SectionOnboard  Section = "onboard"
+	SectionBMC      Section = "bmc"
+	SectionTPM      Section = "tpm"
+	SectionFirmware Section = "firmware"
+)
+
+// ErrUnknownSection is reported when asked to gather a section that doesn't exist.
+var ErrUnknownSection = errors.New("unknown section")
+
+// task gathers one section of system information.
+type task struct {
+	section Section
+	deps    []Section
+	collect func(si *SysInfo, p *probe)
+}
+
+// Built-in collectors, every collector must come after the collectors it depends on. Collectors run concurrently,
+// each one on its own copy of SysInfo, as soon as the collectors they depend on are finished.
+var collectors = []task{
+	// DMI info
+	{SectionProduct, nil, (*SysInfo).getProductInfo},
+	{SectionBoard, nil, (*SysInfo).getBoardInfo},
+	{SectionChassis, nil, (*SysInfo).getChassisInfo},
+	{SectionBIOS, nil, (*SysInfo).getBIOSInfo},
+
+	// SMBIOS info
+	{SectionMemory, nil, (*SysInfo).getMemoryInfo},
+	{SectionSlots, nil, (*SysInfo).getSlotInfo},
+	{SectionPower, nil, (*SysInfo).getPowerInfo},
+	{SectionBMC, nil, (*SysInfo).getBMCInfo},
+	{SectionTPM, nil, (*SysInfo).getTPMInfo},
+	{SectionFirmware, nil, (*SysInfo).getFirmwareInfo},
+
+	// Node info, hypervisor detection needs BIOS vendor
+	{SectionNode, []Section{SectionBIOS}, (*SysInfo).getNodeInfo},
+
+	// Hardware info
+	{SectionCPU, nil, (*SysInfo).getCPUInfo},
+	{SectionStorage, nil, (*SysInfo).getStorageInfo},
+	{SectionNetwork, nil, (*SysInfo).getNetworkInfo},
+
+	// Onboard devices are linked to network and storage devices
+	{SectionOnboard, []Section{SectionNetwork, SectionStorage}, (*SysInfo).getOnboardInfo},
+
+	// Software info
+	{SectionOS, nil, (*SysInfo).getOSInfo},
+	{SectionKernel, nil, (*SysInfo).getKernelInfo},
+}
+
+// Copy section gathered by a collector from its working copy of SysInfo.
+func (si *SysInfo) mergeSection(s Section, src *SysInfo) {
+	switch s {
+	case SectionNode:
+		si.Node = src.Node
+	case SectionOS:
+		si.OS = src.OS
+	case SectionKernel:
+		si.Kernel = src.Kernel
+	case SectionProduct:
+		si.Product = src.Product
+	case SectionBoard:
+		si.Board = src.Board
+	case SectionChassis:
+		si.Chassis = src.Chassis
+	case SectionBIOS:
+		si.BIOS = src.BIOS
+	case SectionCPU:
+		si.CPU = src.CPU
+	case SectionMemory:
+		si.Memory = src.Memory
+	case SectionStorage:
+		si.Storage = src.Storage
+	case SectionNetwork:
+		si.Network = src.Network
+	case SectionSlots:
+		si.Slots = src.Slots
+	case SectionPower:
+		si.Power = src.Power
+	case SectionBMC:
+		si.BMC = src.BMC
+	case SectionTPM:
+		si.TPM = src.TPM
+	case SectionFirmware:
+		si.Firmware = src.Firmware
+	case SectionOnboard:
+		si.Onboard = src.Onboard
+		si.Network = src.Network
+		si.Storage = src.Storage
+	default:
+		if v, ok := src.Extensions[string(s)]; ok {
+			if si.Extensions == nil {
+				si.Extensions = make(map[string]any)
+			}
+			si.Extensions[string(s)] = v
+		}
+	}
+}
+
+// Status of a gathered section, judging by failures of its collector, and its content. Missing files are normal (not
+// every system has every file, and finding nothing is a valid result), anything else means that some information is
+// missing, and if nothing was found at all, the section is unavailable.
+func (si *SysInfo) sectionStatus(s Section, errs Errors) Status {
+	status := StatusComplete
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case errors.Is(err, fs.ErrPermission):
+			if status == StatusComplete {
+				status = StatusPartial
+			}
+		default:
+			status = StatusIncomplete
+		}
+	}
+
+	if status != StatusComplete && si.sectionEmpty(s) {
+		return StatusUnavailable
+	}
+
+	return status
+}
+
+func (si *SysInfo) sectionEmpty(s Section) bool {
+	v := reflect.ValueOf(si).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if jsonName(v.Type().Field(i)) == string(s) {
+			f := v.Field(i)
+			return f.IsZero() || (f.Kind() == reflect.Slice && f.Len() == 0)
+		}
+	}
+
+	_, ok := si.Extensions[string(s)]
+	return !ok
+}
+
+// Sections returns all known sections, including the ones gathered by registered collectors.
+func Sections() []Section {
+	all := tasks(context.Background())
+	sections := make([]Section, len(all))
+	for i, t := range all {
+		sections[i] = t.section
+	}
+
+	return sections
+}
+
+// Resolve requested sections and their dependencies, no sections means all of them.
+func resolveSections(all []task, sections []Section) (map[Section]bool, Errors) {
+	selected := make(map[Section]bool)
+	if len(sections) == 0 {
+		for _, t := range all {
+			selected[t.section] = true
+		}
+		return selected, nil
+	}
+
+	var errs Errors
+	for _, s := range sections {
+		selected[s] = true
+	}
+
+	// Walk backwards, so that dependencies of dependencies get selected, too.
+	for i := len(all) - 1; i >= 0; i-- {
+		if selected[all[i].section] {
+			for _, dep := range all[i].deps {
+				selected[dep] = true
+			}
+		}
+	}
+
+	for _, s := range sections {
+		known := false
+		for _, t := range all {
+			if t.section == s {
+				known = true
+				break
+			}
+		}
+		if !known {
+			errs = append(errs, &CollectorError{Collector: string(s), Op: "collect", Err: ErrUnknownSection})
+		}
+	}
+
+	return selected, errs
+}
+
+// Dependencies that are not selected don't have to be waited for.
+func dependenciesFinished(deps []Section, selected, finished map[Section]bool) bool {
+	for _, dep := range deps {
+		if selected[dep] && !finished[dep] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,181 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zcalusic/sysinfo"
+)
+
+// Sections recorded in Meta, sorted.
+func gathered(si *sysinfo.SysInfo) []sysinfo.Section {
+	var sections []sysinfo.Section
+	for s := range si.Meta.Sections {
+		sections = append(sections, s)
+	}
+	sort.Slice(sections, func(i, j int) bool { return sections[i] < sections[j] })
+
+	return sections
+}
+
+func TestCollectSections(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/dmi/id/bios_vendor":  "SeaBIOS",
+		"sys/class/dmi/id/board_name":   "Test Board",
+		"sys/class/dmi/id/product_name": "Test",
+	})
+
+	// Node depends on BIOS (hypervisor detection), which gets gathered, too.
+	si := sysinfo.SysInfo{Root: root}
+	if err := si.Collect(sysinfo.SectionNode); errors.Is(err, sysinfo.ErrUnknownSection) {
+		t.Fatal(err)
+	}
+	if want := []sysinfo.Section{sysinfo.SectionBIOS, sysinfo.SectionNode}; !reflect.DeepEqual(gathered(&si), want) {
+		t.Errorf("gathered sections = %v, want %v", gathered(&si), want)
+	}
+	if si.BIOS.Vendor != "SeaBIOS" || si.Board.Name != "" || si.Product.Name != "" {
+		t.Errorf("gathered BIOS = %+v, Board = %+v, Product = %+v", si.BIOS, si.Board, si.Product)
+	}
+
+	// Unknown sections are reported, known ones are still gathered.
+	si = sysinfo.SysInfo{Root: root}
+	err := si.Collect(sysinfo.SectionBoard, "bogus")
+	if !errors.Is(err, sysinfo.ErrUnknownSection) {
+		t.Errorf("Collect(bogus) = %v, want %v", err, sysinfo.ErrUnknownSection)
+	}
+	if want := []sysinfo.Section{sysinfo.SectionBoard}; !reflect.DeepEqual(gathered(&si), want) {
+		t.Errorf("gathered sections = %v, want %v", gathered(&si), want)
+	}
+	if si.Board.Name != "Test Board" {
+		t.Errorf("Board.Name = %q, want %q", si.Board.Name, "Test Board")
+	}
+}
+
+type slowKey struct{}
+
+// Collector that blocks until the context is done, if the context asks it to.
+type slowCollector struct{}
+
+func (slowCollector) Name() string { return "slow" }
+
+func (slowCollector) Collect(ctx context.Context, _ *sysinfo.SysInfo) (any, error) {
+	if ctx.Value(slowKey{}) == nil {
+		return nil, nil
+	}
+
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func init() {
+	sysinfo.Register(slowCollector{})
+}
+
+func TestCollectContext(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/dmi/id/board_name": "Test Board",
+	})
+
+	// Nothing is gathered with context already canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	si := sysinfo.SysInfo{Root: root}
+	if err := si.GetSysInfoContext(ctx, sysinfo.SectionBoard); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetSysInfoContext() = %v, want %v", err, context.Canceled)
+	}
+	if si.Meta.Sections[sysinfo.SectionBoard] != sysinfo.StatusUnavailable || si.Board.Name != "" {
+		t.Errorf("Board = %+v, status %q", si.Board, si.Meta.Sections[sysinfo.SectionBoard])
+	}
+
+	// Sections finished in time are kept, the rest is abandoned.
+	ctx, cancel = context.WithTimeout(context.WithValue(context.Background(), slowKey{}, true), 50*time.Millisecond)
+	defer cancel()
+
+	si = sysinfo.SysInfo{Root: root}
+	if err := si.GetSysInfoContext(ctx, sysinfo.SectionBoard, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetSysInfoContext() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if si.Meta.Sections[sysinfo.SectionBoard] != sysinfo.StatusComplete || si.Board.Name != "Test Board" {
+		t.Errorf("Board = %+v, status %q", si.Board, si.Meta.Sections[sysinfo.SectionBoard])
+	}
+	if si.Meta.Sections["slow"] != sysinfo.StatusUnavailable {
+		t.Errorf("slow status = %q, want %q", si.Meta.Sections["slow"], sysinfo.StatusUnavailable)
+	}
+}
+
+func TestSectionStatus(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/dmi/id/board_name":     "Test Board",
+		"sys/class/dmi/id/chassis_type":   "tower",
+		"sys/class/dmi/id/chassis_vendor": "Acme",
+	})
+
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect(sysinfo.SectionBoard, sysinfo.SectionChassis, sysinfo.SectionBMC, sysinfo.SectionTPM)
+
+	want := map[sysinfo.Section]sysinfo.Status{
+		sysinfo.SectionBoard:   sysinfo.StatusComplete,   // missing files are normal
+		sysinfo.SectionChassis: sysinfo.StatusIncomplete, // chassis type couldn't be parsed
+		sysinfo.SectionBMC:     sysinfo.StatusComplete,   // correctly found nothing
+		sysinfo.SectionTPM:     sysinfo.StatusComplete,
+	}
+	if !reflect.DeepEqual(si.Meta.Sections, want) {
+		t.Errorf("Meta.Sections = %v, want %v", si.Meta.Sections, want)
+	}
+}
+
+func TestSMBIOSExtrasStatus(t *testing.T) {
+	root := fixture(t, map[string]string{
+		"sys/class/dmi/id/chassis_type": "23",
+		"sys/class/dmi/id/product_name": "Test",
+		"sys/firmware/dmi/tables/DMI":   "\x01\x02\x00\x00", // truncated structure
+	})
+
+	// Sections gathered from sysfs don't suffer from a broken SMBIOS table, only their extras are missing.
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect(sysinfo.SectionProduct, sysinfo.SectionChassis)
+
+	want := map[sysinfo.Section]sysinfo.Status{
+		sysinfo.SectionProduct: sysinfo.StatusComplete,
+		sysinfo.SectionChassis: sysinfo.StatusComplete,
+	}
+	if !reflect.DeepEqual(si.Meta.Sections, want) {
+		t.Errorf("Meta.Sections = %v, want %v", si.Meta.Sections, want)
+	}
+}
+
+func TestUnselectedSectionJSON(t *testing.T) {
+	si := sysinfo.SysInfo{Root: fixture(t, nil)}
+	_ = si.Collect(sysinfo.SectionOS)
+
+	data, err := json.Marshal(si.Product)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{}" {
+		t.Errorf("unselected product = %s, want {}", data)
+	}
+
+	if data, _ = json.Marshal(si.BMC); string(data) != "{}" {
+		t.Errorf("unselected BMC = %s, want {}", data)
+	}
+	if data, _ = json.Marshal(si.TPM); string(data) != "{}" {
+		t.Errorf("unselected TPM = %s, want {}", data)
+	}
+
+	si.Product.UUID = uuid.MustParse("4c4c4544-0042-3510-8052-b4c04f4a4e32")
+	if data, _ = json.Marshal(si.Product); string(data) != `{"uuid":"4c4c4544-0042-3510-8052-b4c04f4a4e32"}` {
+		t.Errorf("product = %s", data)
+	}
+}
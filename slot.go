@@ -0,0 +1,126 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// Slot information, one for every expansion slot, free or in use.
+type Slot struct {
+	Designation     string   `json:"designation,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Width           string   `json:"width,omitempty"` // data bus width
+	Usage           string   `json:"usage,omitempty"`
+	Length          string   `json:"length,omitempty"`
+	ID              uint     `json:"id,omitempty"`
+	Characteristics []string `json:"characteristics,omitempty"`
+	Address         string   `json:"address,omitempty"` // PCI address of the slot, as reported by SMBIOS
+	Device          string   `json:"device,omitempty"`  // PCI address of the device in the slot
+	PCIID           string   `json:"pciid,omitempty"`   // PCI vendor and device ID of the device in the slot
+	Driver          string   `json:"driver,omitempty"`
+}
+
+var rePCIAddress = regexp.MustCompile(`^[[:xdigit:]]{4}:[[:xdigit:]]{2}:[[:xdigit:]]{2}\.[0-7]$`)
+
+// Find the PCI device occupying the slot. SMBIOS points either to the device itself, or to the bridge (root port) the
+// slot hangs off. The port links to device 0 on its secondary bus, so the device is function 0 of device 0 behind the
+// bridge (other functions belong to the same card), or failing that, the lowest addressed device behind the bridge.
+func (slot *Slot) findDevice(p *probe) {
+	sysBusPCI := "/sys/bus/pci/devices"
+	dir := path.Join(sysBusPCI, slot.Address)
+	if _, err := p.stat(dir); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			p.failPath(err)
+		}
+		return
+	}
+
+	// PCI-to-PCI bridge class, from /usr/include/linux/pci_ids.h
+	if strings.HasPrefix(p.slurpOptional(path.Join(dir, "class")), "0x0604") {
+		entries, err := p.readDir(dir)
+		if err != nil {
+			p.failPath(err)
+			return
+		}
+
+		var child string
+		for _, e := range entries {
+			if !rePCIAddress.MatchString(e.Name()) {
+				continue
+			}
+			if strings.HasSuffix(e.Name(), ":00.0") {
+				child = e.Name()
+				break
+			}
+			if child == "" || e.Name() < child {
+				child = e.Name()
+			}
+		}
+		if child == "" {
+			return
+		}
+		dir = path.Join(sysBusPCI, child)
+	}
+
+	slot.Device = path.Base(dir)
+
+	vendor := strings.TrimPrefix(p.slurpOptional(path.Join(dir, "vendor")), "0x")
+	device := strings.TrimPrefix(p.slurpOptional(path.Join(dir, "device")), "0x")
+	if vendor != "" && device != "" {
+		slot.PCIID = vendor + ":" + device
+	}
+
+	if driver, err := p.readlink(path.Join(dir, "driver")); err == nil {
+		slot.Driver = path.Base(driver)
+	}
+}
+
+func (si *SysInfo) getSlotInfo(p *probe) {
+	t := getSMBIOS(p)
+	if t == nil {
+		return
+	}
+
+	si.Slots = nil
+	for _, s := range t.Type(smbios.TypeSystemSlot) {
+		ss, err := s.SystemSlot()
+		if err != nil {
+			p.failSMBIOS(err)
+			continue
+		}
+
+		slot := Slot{
+			Designation:     ss.Designation,
+			ID:              uint(ss.ID),
+			Characteristics: ss.Characteristics.Strings(),
+			Address:         ss.PCIAddress(),
+		}
+		if ss.Type != 0 {
+			slot.Type = ss.Type.String()
+		}
+		if ss.Width != 0 {
+			slot.Width = ss.Width.String()
+		}
+		if ss.Usage != 0 {
+			slot.Usage = ss.Usage.String()
+		}
+		if ss.Length != 0 {
+			slot.Length = ss.Length.String()
+		}
+
+		if slot.Address != "" {
+			slot.findDevice(p)
+		}
+
+		si.Slots = append(si.Slots, slot)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestSlotDevices(t *testing.T) {
+	// Slots pointing to the device itself, to a bridge with a multi-function card behind it, and to an empty bridge.
+	slot := func(designation string, bus, devfn uint8) string {
+		return structure(9, 0x0900+uint16(bus), formatted(0x11, map[int]any{
+			0x04: uint8(1), 0x0f: bus, 0x10: devfn,
+		}), designation)
+	}
+
+	root := fixture(t, map[string]string{
+		"sys/firmware/dmi/tables/DMI": slot("PCIE1", 0x01, 0x00) + slot("PCIE2", 0x00, 0x03<<3) +
+			slot("PCIE3", 0x00, 0x1c<<3) + endOfTable,
+
+		"sys/bus/pci/devices/0000:01:00.0/class":  "0x020000",
+		"sys/bus/pci/devices/0000:01:00.0/vendor": "0x8086",
+		"sys/bus/pci/devices/0000:01:00.0/device": "0x1572",
+
+		"sys/bus/pci/devices/0000:00:03.0/class":              "0x060400",
+		"sys/bus/pci/devices/0000:00:03.0/0000:02:00.1/class": "0x020000",
+		"sys/bus/pci/devices/0000:00:03.0/0000:02:00.0/class": "0x020000",
+		"sys/bus/pci/devices/0000:02:00.0/vendor":             "0x15b3",
+		"sys/bus/pci/devices/0000:02:00.0/device":             "0x1015",
+
+		"sys/bus/pci/devices/0000:00:1c.0/class": "0x060400",
+	})
+	if err := os.Symlink("../../../../bus/pci/drivers/i40e", filepath.Join(root,
+		"sys/bus/pci/devices/0000:01:00.0/driver")); err != nil {
+		t.Fatal(err)
+	}
+
+	si := sysinfo.SysInfo{Root: root}
+	_ = si.Collect(sysinfo.SectionSlots)
+
+	want := []sysinfo.Slot{
+		{Designation: "PCIE1", Address: "0000:01:00.0", Device: "0000:01:00.0", PCIID: "8086:1572", Driver: "i40e"},
+		{Designation: "PCIE2", Address: "0000:00:03.0", Device: "0000:02:00.0", PCIID: "15b3:1015"},
+		{Designation: "PCIE3", Address: "0000:00:1c.0"},
+	}
+	if !reflect.DeepEqual(si.Slots, want) {
+		t.Errorf("Slots = %+v, want %+v", si.Slots, want)
+	}
+}
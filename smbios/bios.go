@@ -0,0 +1,98 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+// BIOSCharacteristics bits of BIOS.
+type BIOSCharacteristics uint64
+
+// SMBIOS Reference Specification Version 3.8.0, 7.1.1 (bits 32-63 are reserved for BIOS and system vendors)
+var biosCharacteristics = []string{
+	"", "", "Unknown", "BIOS characteristics not supported", "ISA", "MCA", "EISA", "PCI", "PC Card (PCMCIA)",
+	"Plug and Play", "APM", "BIOS is upgradeable", "BIOS shadowing is allowed", "VL-VESA", "ESCD",
+	"Boot from CD", "Selectable boot", "BIOS ROM is socketed", "Boot from PC Card", "EDD",
+	"Japanese floppy for NEC 9800 1.2 MB (int 13h)", "Japanese floppy for Toshiba 1.2 MB (int 13h)",
+	"5.25\"/360 kB floppy (int 13h)", "5.25\"/1.2 MB floppy (int 13h)", "3.5\"/720 kB floppy (int 13h)",
+	"3.5\"/2.88 MB floppy (int 13h)", "Print screen (int 5h)", "8042 keyboard (int 9h)", "Serial (int 14h)",
+	"Printer (int 17h)", "CGA/mono video (int 10h)", "NEC PC-98",
+}
+
+// Strings returns names of all the set bits.
+func (c BIOSCharacteristics) Strings() []string {
+	return bitNames(biosCharacteristics, uint64(c))
+}
+
+// BIOSCharacteristicsExt bits of BIOS, extension byte 1 in the low and extension byte 2 in the high byte.
+type BIOSCharacteristicsExt uint16
+
+// SMBIOS Reference Specification Version 3.8.0, 7.1.2.1 and 7.1.2.2
+var biosCharacteristicsExt = []string{
+	"ACPI", "USB legacy", "AGP", "I2O boot", "LS-120 SuperDisk boot", "ATAPI ZIP drive boot", "IEEE 1394 boot",
+	"Smart battery", "BIOS boot specification", "Function key-initiated network boot", "Targeted content distribution",
+	"UEFI", "Virtual machine", "Manufacturing mode supported", "Manufacturing mode enabled",
+}
+
+// Strings returns names of all the set bits.
+func (c BIOSCharacteristicsExt) Strings() []string {
+	return bitNames(biosCharacteristicsExt, uint64(c))
+}
+
+// BIOS information (type 0).
+type BIOS struct {
+	Handle               uint16
+	Vendor               string
+	Version              string
+	StartingSegment      uint16 // segment of the runtime BIOS in the legacy address space, 0 for UEFI
+	ReleaseDate          string
+	ROMSize              uint64 // bytes
+	Characteristics      BIOSCharacteristics
+	CharacteristicsExt   BIOSCharacteristicsExt
+	BIOSMajorRelease     uint8 // 0xff if not supported
+	BIOSMinorRelease     uint8 // 0xff if not supported
+	FirmwareMajorRelease uint8 // embedded controller firmware, 0xff if not supported
+	FirmwareMinorRelease uint8 // embedded controller firmware, 0xff if not supported
+}
+
+// BIOS decodes BIOS information structure.
+func (s *Structure) BIOS() (*BIOS, error) {
+	if err := s.check(TypeBIOS, 0x12); err != nil {
+		return nil, err
+	}
+
+	b := &BIOS{
+		Handle:               s.Handle,
+		Vendor:               s.StringAt(0x04),
+		Version:              s.StringAt(0x05),
+		StartingSegment:      s.Word(0x06),
+		ReleaseDate:          s.StringAt(0x08),
+		ROMSize:              (uint64(s.Byte(0x09)) + 1) << 16,
+		Characteristics:      BIOSCharacteristics(s.QWord(0x0a)),
+		CharacteristicsExt:   BIOSCharacteristicsExt(uint16(s.Byte(0x12)) | uint16(s.Byte(0x13))<<8),
+		BIOSMajorRelease:     0xff,
+		BIOSMinorRelease:     0xff,
+		FirmwareMajorRelease: 0xff,
+		FirmwareMinorRelease: 0xff,
+	}
+
+	// Release fields are available since SMBIOS 2.4.
+	if len(s.Formatted) >= 0x18 {
+		b.BIOSMajorRelease = s.Byte(0x14)
+		b.BIOSMinorRelease = s.Byte(0x15)
+		b.FirmwareMajorRelease = s.Byte(0x16)
+		b.FirmwareMinorRelease = s.Byte(0x17)
+	}
+
+	// ROM size of 16 MB and more is in the extended field (SMBIOS 3.1+), in MB or GB.
+	if s.Byte(0x09) == 0xff && len(s.Formatted) >= 0x1a {
+		size := s.Word(0x18)
+		switch size >> 14 {
+		case 0:
+			b.ROMSize = uint64(size&0x3fff) << 20
+		case 1:
+			b.ROMSize = uint64(size&0x3fff) << 30
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+// CacheType of a cache.
+type CacheType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.8.4
+var cacheTypes = []string{
+	"Other", "Unknown", "Instruction", "Data", "Unified",
+}
+
+func (t CacheType) String() string {
+	return enumString(cacheTypes, uint(t))
+}
+
+// CacheAssociativity of a cache.
+type CacheAssociativity uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.8.5
+var cacheAssociativities = []string{
+	"Other", "Unknown", "Direct Mapped", "2-way Set-Associative", "4-way Set-Associative", "Fully Associative",
+	"8-way Set-Associative", "16-way Set-Associative", "12-way Set-Associative", "24-way Set-Associative",
+	"32-way Set-Associative", "48-way Set-Associative", "64-way Set-Associative", "20-way Set-Associative",
+}
+
+var cacheWays = []uint{0, 0, 1, 2, 4, 0, 8, 16, 12, 24, 32, 48, 64, 20}
+
+func (a CacheAssociativity) String() string {
+	return enumString(cacheAssociativities, uint(a))
+}
+
+// Ways returns number of ways of a set-associative (or direct mapped) cache, 0 if unknown or fully associative.
+func (a CacheAssociativity) Ways() uint {
+	if a >= 1 && int(a) <= len(cacheWays) {
+		return cacheWays[a-1]
+	}
+
+	return 0
+}
+
+// Cache information (type 7).
+type Cache struct {
+	Handle          uint16
+	Socket          string // socket designation, like "L1 Cache" or "CPU1 L2"
+	Level           uint8  // 1 for L1 cache, etc.
+	Enabled         bool
+	MaxSize         uint64                // bytes
+	InstalledSize   uint64                // bytes, 0 if not installed
+	ErrorCorrection MemoryErrorCorrection // same values as for physical memory arrays
+	Type            CacheType
+	Associativity   CacheAssociativity
+}
+
+// Decode cache size, word (2 if needed) with granularity bit at the top, either 1K or 64K.
+func cacheSize(size uint16, size2 uint32) uint64 {
+	if size == 0xffff {
+		if size2&0x80000000 != 0 {
+			return uint64(size2&0x7fffffff) << 16
+		}
+
+		return uint64(size2) << 10
+	}
+
+	if size&0x8000 != 0 {
+		return uint64(size&0x7fff) << 16
+	}
+
+	return uint64(size) << 10
+}
+
+// Cache decodes cache information structure.
+func (s *Structure) Cache() (*Cache, error) {
+	if err := s.check(TypeCache, 0x0f); err != nil {
+		return nil, err
+	}
+
+	config := s.Word(0x05)
+
+	return &Cache{
+		Handle:          s.Handle,
+		Socket:          s.StringAt(0x04),
+		Level:           uint8(config&0x07) + 1,
+		Enabled:         config&0x80 != 0,
+		MaxSize:         cacheSize(s.Word(0x07), s.DWord(0x13)),
+		InstalledSize:   cacheSize(s.Word(0x09), s.DWord(0x17)),
+		ErrorCorrection: MemoryErrorCorrection(s.Byte(0x10)),
+		Type:            CacheType(s.Byte(0x11)),
+		Associativity:   CacheAssociativity(s.Byte(0x12)),
+	}, nil
+}
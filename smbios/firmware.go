@@ -0,0 +1,146 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// FirmwareVersionFormat of a firmware component version string.
+type FirmwareVersionFormat uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.46.1
+var firmwareVersionFormats = []string{"Free-form", "Major.Minor", "32-bit hex", "64-bit hex"}
+
+func (f FirmwareVersionFormat) String() string {
+	if f >= 0x80 {
+		return "OEM-specific"
+	}
+
+	return enumString(firmwareVersionFormats, uint(f)+1)
+}
+
+// FirmwareIDFormat of a firmware component ID string.
+type FirmwareIDFormat uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.46.2
+var firmwareIDFormats = []string{"Free-form", "UEFI ESRT FwClass GUID"}
+
+func (f FirmwareIDFormat) String() string {
+	if f >= 0x80 {
+		return "OEM-specific"
+	}
+
+	return enumString(firmwareIDFormats, uint(f)+1)
+}
+
+// FirmwareCharacteristics bits of a firmware component.
+type FirmwareCharacteristics uint16
+
+// SMBIOS Reference Specification Version 3.8.0, 7.46.3
+var firmwareCharacteristics = []string{"Updatable", "Write-Protect"}
+
+// Strings returns names of all the set bits.
+func (c FirmwareCharacteristics) Strings() []string {
+	return bitNames(firmwareCharacteristics, uint64(c))
+}
+
+// FirmwareState of a firmware component.
+type FirmwareState uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.46.4
+var firmwareStates = []string{
+	"Other", "Unknown", "Disabled", "Enabled", "Absent", "StandbyOffline", "StandbySpare", "UnavailableOffline",
+}
+
+func (st FirmwareState) String() string {
+	return enumString(firmwareStates, uint(st))
+}
+
+// FirmwareInventory information (type 45).
+type FirmwareInventory struct {
+	Handle               uint16
+	ComponentName        string
+	Version              string
+	VersionFormat        FirmwareVersionFormat
+	ID                   string
+	IDFormat             FirmwareIDFormat
+	ReleaseDate          string
+	Manufacturer         string
+	LowestVersion        string // lowest supported firmware version
+	ImageSize            uint64 // bytes, 0xffffffffffffffff if unknown
+	Characteristics      FirmwareCharacteristics
+	State                FirmwareState
+	AssociatedComponents []uint16 // handles of the structures (devices) the firmware belongs to
+}
+
+// FirmwareInventory decodes firmware inventory information structure.
+func (s *Structure) FirmwareInventory() (*FirmwareInventory, error) {
+	if err := s.check(TypeFirmwareInventory, 0x18); err != nil {
+		return nil, err
+	}
+
+	fi := &FirmwareInventory{
+		Handle:          s.Handle,
+		ComponentName:   s.StringAt(0x04),
+		Version:         s.StringAt(0x05),
+		VersionFormat:   FirmwareVersionFormat(s.Byte(0x06)),
+		ID:              s.StringAt(0x07),
+		IDFormat:        FirmwareIDFormat(s.Byte(0x08)),
+		ReleaseDate:     s.StringAt(0x09),
+		Manufacturer:    s.StringAt(0x0a),
+		LowestVersion:   s.StringAt(0x0b),
+		ImageSize:       s.QWord(0x0c),
+		Characteristics: FirmwareCharacteristics(s.Word(0x14)),
+		State:           FirmwareState(s.Byte(0x16)),
+	}
+
+	n := int(s.Byte(0x17))
+	if 0x18+2*n > len(s.Formatted) {
+		return nil, fmt.Errorf("smbios: structure type %d (handle %#04x) associated components truncated", s.Type,
+			s.Handle)
+	}
+	for i := 0; i < n; i++ {
+		fi.AssociatedComponents = append(fi.AssociatedComponents, s.Word(0x18+2*i))
+	}
+
+	return fi, nil
+}
+
+// StringPropertyID of a string property.
+type StringPropertyID uint16
+
+func (id StringPropertyID) String() string {
+	switch {
+	case id == 1:
+		return "UEFI device path"
+	case id >= 0xc000:
+		return fmt.Sprintf("OEM-specific (0x%04x)", uint16(id))
+	case id >= 0x8000:
+		return fmt.Sprintf("BIOS vendor-specific (0x%04x)", uint16(id))
+	}
+
+	return fmt.Sprintf("0x%04x", uint16(id))
+}
+
+// StringProperty information (type 46), an additional string attached to another (parent) structure.
+type StringProperty struct {
+	Handle       uint16
+	ID           StringPropertyID
+	Value        string
+	ParentHandle uint16
+}
+
+// StringProperty decodes string property structure.
+func (s *Structure) StringProperty() (*StringProperty, error) {
+	if err := s.check(TypeStringProperty, 0x09); err != nil {
+		return nil, err
+	}
+
+	return &StringProperty{
+		Handle:       s.Handle,
+		ID:           StringPropertyID(s.Word(0x04)),
+		Value:        s.StringAt(0x06),
+		ParentHandle: s.Word(0x07),
+	}, nil
+}
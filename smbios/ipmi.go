@@ -0,0 +1,98 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// IPMIInterfaceType of a baseboard management controller.
+type IPMIInterfaceType uint8
+
+// IPMIInterfaceSSIF is the SMBus system interface, the only one not using I/O or memory-mapped registers.
+const IPMIInterfaceSSIF IPMIInterfaceType = 4
+
+// SMBIOS Reference Specification Version 3.8.0, 7.39.1
+var ipmiInterfaceTypes = []string{
+	"KCS (Keyboard Controller Style)", "SMIC (Server Management Interface Chip)", "BT (Block Transfer)",
+	"SSIF (SMBus System Interface)",
+}
+
+func (t IPMIInterfaceType) String() string {
+	if t == 0 {
+		return "Unknown"
+	}
+
+	return enumString(ipmiInterfaceTypes, uint(t))
+}
+
+// IPMIRegisterSpacing of a baseboard management controller.
+type IPMIRegisterSpacing uint8
+
+var ipmiRegisterSpacings = []string{"Successive byte boundaries", "32-bit boundaries", "16-byte boundaries"}
+
+func (r IPMIRegisterSpacing) String() string {
+	return enumString(ipmiRegisterSpacings, uint(r)+1)
+}
+
+// IPMIDevice information (type 38).
+type IPMIDevice struct {
+	Handle           uint16
+	InterfaceType    IPMIInterfaceType
+	SpecRevision     Version // only major and minor
+	I2CAddress       uint8   // target address on the I2C bus (7-bit address shifted left)
+	NVStorageAddress uint8   // 0xff if there is no non-volatile storage device
+	BaseAddress      uint64  // for SSIF the SMBus address
+	IOSpace          bool    // base address is in I/O space, otherwise memory-mapped
+	RegisterSpacing  IPMIRegisterSpacing
+	Interrupt        uint8 // 0 if unspecified
+}
+
+// IPMIDevice decodes IPMI device information structure.
+func (s *Structure) IPMIDevice() (*IPMIDevice, error) {
+	if err := s.check(TypeIPMIDevice, 0x10); err != nil {
+		return nil, err
+	}
+
+	rev := s.Byte(0x05)
+	d := &IPMIDevice{
+		Handle:           s.Handle,
+		InterfaceType:    IPMIInterfaceType(s.Byte(0x04)),
+		SpecRevision:     Version{Major: rev >> 4, Minor: rev & 0x0f},
+		I2CAddress:       s.Byte(0x06),
+		NVStorageAddress: s.Byte(0x07),
+		BaseAddress:      s.QWord(0x08),
+	}
+
+	// SSIF base address is the SMBus address, the others have the address space in the LS-bit, and the real LS-bit,
+	// register spacing and interrupt in the modifier byte (SMBIOS 2.3+).
+	if d.InterfaceType != IPMIInterfaceSSIF {
+		d.IOSpace = d.BaseAddress&1 != 0
+		d.BaseAddress &^= 1
+
+		if len(s.Formatted) >= 0x12 {
+			modifier := s.Byte(0x10)
+			d.BaseAddress |= uint64(modifier>>4) & 1
+			d.RegisterSpacing = IPMIRegisterSpacing(modifier >> 6)
+			if modifier&0x08 != 0 {
+				d.Interrupt = s.Byte(0x11)
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// Address returns the base address formatted the way dmidecode does, like "0x0000000000000CA2 (I/O)".
+func (d *IPMIDevice) Address() string {
+	if d.InterfaceType == IPMIInterfaceSSIF {
+		return fmt.Sprintf("0x%02X (SMBus)", d.BaseAddress>>1)
+	}
+
+	space := "Memory-mapped"
+	if d.IOSpace {
+		space = "I/O"
+	}
+
+	return fmt.Sprintf("0x%016X (%s)", d.BaseAddress, space)
+}
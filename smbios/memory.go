@@ -0,0 +1,249 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// MemoryArrayLocation of a physical memory array.
+type MemoryArrayLocation uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.17.1
+var memoryArrayLocations = map[MemoryArrayLocation]string{
+	0x01: "Other", 0x02: "Unknown", 0x03: "System board or motherboard", 0x04: "ISA add-on card",
+	0x05: "EISA add-on card", 0x06: "PCI add-on card", 0x07: "MCA add-on card", 0x08: "PCMCIA add-on card",
+	0x09: "Proprietary add-on card", 0x0a: "NuBus", 0xa0: "PC-98/C20 add-on card", 0xa1: "PC-98/C24 add-on card",
+	0xa2: "PC-98/E add-on card", 0xa3: "PC-98/Local bus add-on card", 0xa4: "CXL add-on card",
+}
+
+func (l MemoryArrayLocation) String() string {
+	if name, ok := memoryArrayLocations[l]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%02x", uint8(l))
+}
+
+// MemoryArrayUse of a physical memory array.
+type MemoryArrayUse uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.17.2
+var memoryArrayUses = []string{
+	"Other", "Unknown", "System memory", "Video memory", "Flash memory", "Non-volatile RAM", "Cache memory",
+}
+
+func (u MemoryArrayUse) String() string {
+	return enumString(memoryArrayUses, uint(u))
+}
+
+// MemoryErrorCorrection type of a physical memory array.
+type MemoryErrorCorrection uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.17.3
+var memoryErrorCorrections = []string{
+	"Other", "Unknown", "None", "Parity", "Single-bit ECC", "Multi-bit ECC", "CRC",
+}
+
+func (e MemoryErrorCorrection) String() string {
+	return enumString(memoryErrorCorrections, uint(e))
+}
+
+// PhysicalMemoryArray information (type 16).
+type PhysicalMemoryArray struct {
+	Handle          uint16
+	Location        MemoryArrayLocation
+	Use             MemoryArrayUse
+	ErrorCorrection MemoryErrorCorrection
+	MaxCapacity     uint64 // bytes, 0 if unknown
+	NumDevices      uint16 // number of memory device sockets
+}
+
+// PhysicalMemoryArray decodes physical memory array structure.
+func (s *Structure) PhysicalMemoryArray() (*PhysicalMemoryArray, error) {
+	if err := s.check(TypePhysicalMemoryArray, 0x0f); err != nil {
+		return nil, err
+	}
+
+	a := &PhysicalMemoryArray{
+		Handle:          s.Handle,
+		Location:        MemoryArrayLocation(s.Byte(0x04)),
+		Use:             MemoryArrayUse(s.Byte(0x05)),
+		ErrorCorrection: MemoryErrorCorrection(s.Byte(0x06)),
+		MaxCapacity:     uint64(s.DWord(0x07)) << 10,
+		NumDevices:      s.Word(0x0d),
+	}
+
+	// Capacity of 2 TB and more is in the extended field (SMBIOS 2.7+).
+	if s.DWord(0x07) == 0x80000000 {
+		a.MaxCapacity = s.QWord(0x0f)
+	}
+
+	return a, nil
+}
+
+// MemoryType of a memory device.
+type MemoryType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.18.2
+var memoryTypes = []string{
+	"Other", "Unknown", "DRAM", "EDRAM", "VRAM", "SRAM", "RAM", "ROM", "FLASH",
+	"EEPROM", "FEPROM", "EPROM", "CDRAM", "3DRAM", "SDRAM", "SGRAM", "RDRAM",
+	"DDR", "DDR2", "DDR2 FB-DIMM", "Reserved", "Reserved", "Reserved", "DDR3",
+	"FBD2", "DDR4", "LPDDR", "LPDDR2", "LPDDR3", "LPDDR4", "Logical non-volatile device",
+	"HBM", "HBM2", "DDR5", "LPDDR5", "HBM3",
+}
+
+func (t MemoryType) String() string {
+	return enumString(memoryTypes, uint(t))
+}
+
+// MemoryFormFactor of a memory device.
+type MemoryFormFactor uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.18.1
+var memoryFormFactors = []string{
+	"Other", "Unknown", "SIMM", "SIP", "Chip", "DIP", "ZIP", "Proprietary Card",
+	"DIMM", "TSOP", "Row of chips", "RIMM", "SODIMM", "SRIMM", "FB-DIMM", "Die", "CAMM",
+}
+
+func (f MemoryFormFactor) String() string {
+	return enumString(memoryFormFactors, uint(f))
+}
+
+// MemoryTypeDetail bits of a memory device.
+type MemoryTypeDetail uint16
+
+// SMBIOS Reference Specification Version 3.8.0, 7.18.3
+var memoryTypeDetails = []string{
+	"", "Other", "Unknown", "Fast-paged", "Static column", "Pseudo-static", "RAMBUS", "Synchronous",
+	"CMOS", "EDO", "Window DRAM", "Cache DRAM", "Non-volatile", "Registered (Buffered)",
+	"Unbuffered (Unregistered)", "LRDIMM",
+}
+
+// Strings returns names of all the set bits.
+func (d MemoryTypeDetail) Strings() []string {
+	return bitNames(memoryTypeDetails, uint64(d))
+}
+
+// MemoryDevice information (type 17).
+type MemoryDevice struct {
+	Handle            uint16
+	ArrayHandle       uint16 // handle of the physical memory array (type 16) the device belongs to
+	TotalWidth        uint16 // bits, including ECC, 0 if unknown
+	DataWidth         uint16 // bits, 0 if unknown
+	Size              uint64 // bytes, 0 if no module is installed in the socket
+	SizeUnknown       bool
+	FormFactor        MemoryFormFactor
+	DeviceLocator     string
+	BankLocator       string
+	Type              MemoryType
+	TypeDetail        MemoryTypeDetail
+	Speed             uint32 // maximum data rate in MT/s, 0 if unknown
+	Manufacturer      string
+	SerialNumber      string
+	AssetTag          string
+	PartNumber        string
+	Rank              uint8  // 0 if unknown
+	ConfiguredSpeed   uint32 // configured data rate in MT/s, 0 if unknown
+	MinVoltage        uint16 // mV, 0 if unknown
+	MaxVoltage        uint16 // mV, 0 if unknown
+	ConfiguredVoltage uint16 // mV, 0 if unknown
+}
+
+// MemoryDevice decodes memory device structure.
+func (s *Structure) MemoryDevice() (*MemoryDevice, error) {
+	if err := s.check(TypeMemoryDevice, 0x15); err != nil {
+		return nil, err
+	}
+
+	md := &MemoryDevice{
+		Handle:            s.Handle,
+		ArrayHandle:       s.Word(0x04),
+		TotalWidth:        s.Word(0x08),
+		DataWidth:         s.Word(0x0a),
+		FormFactor:        MemoryFormFactor(s.Byte(0x0e)),
+		DeviceLocator:     s.StringAt(0x10),
+		BankLocator:       s.StringAt(0x11),
+		Type:              MemoryType(s.Byte(0x12)),
+		TypeDetail:        MemoryTypeDetail(s.Word(0x13)),
+		Speed:             uint32(s.Word(0x15)),
+		Manufacturer:      s.StringAt(0x17),
+		SerialNumber:      s.StringAt(0x18),
+		AssetTag:          s.StringAt(0x19),
+		PartNumber:        s.StringAt(0x1a),
+		Rank:              s.Byte(0x1b) & 0x0f,
+		ConfiguredSpeed:   uint32(s.Word(0x20)),
+		MinVoltage:        s.Word(0x22),
+		MaxVoltage:        s.Word(0x24),
+		ConfiguredVoltage: s.Word(0x26),
+	}
+
+	if md.TotalWidth == 0xffff {
+		md.TotalWidth = 0
+	}
+	if md.DataWidth == 0xffff {
+		md.DataWidth = 0
+	}
+
+	switch size := s.Word(0x0c); {
+	case size == 0xffff:
+		md.SizeUnknown = true
+	case size == 0x7fff:
+		md.Size = uint64(s.DWord(0x1c)&0x7fffffff) << 20
+	case size&0x8000 != 0:
+		md.Size = uint64(size&0x7fff) << 10
+	default:
+		md.Size = uint64(size) << 20
+	}
+
+	// Data rates that don't fit in a word are in the extended fields (SMBIOS 3.3+).
+	if md.Speed == 0xffff {
+		md.Speed = s.DWord(0x54)
+	}
+	if md.ConfiguredSpeed == 0xffff {
+		md.ConfiguredSpeed = s.DWord(0x58)
+	}
+
+	return md, nil
+}
+
+// MemoryArrayMappedAddress information (type 19).
+type MemoryArrayMappedAddress struct {
+	Handle         uint16
+	StartAddress   uint64 // bytes
+	EndAddress     uint64 // bytes, address of the last byte
+	ArrayHandle    uint16 // handle of the physical memory array (type 16) the range belongs to
+	PartitionWidth uint8  // number of memory devices that form a single row
+}
+
+// Size of the address range in bytes.
+func (m *MemoryArrayMappedAddress) Size() uint64 {
+	if m.EndAddress < m.StartAddress {
+		return 0
+	}
+
+	return m.EndAddress - m.StartAddress + 1
+}
+
+// MemoryArrayMappedAddress decodes memory array mapped address structure.
+func (s *Structure) MemoryArrayMappedAddress() (*MemoryArrayMappedAddress, error) {
+	if err := s.check(TypeMemoryArrayMappedAddress, 0x0f); err != nil {
+		return nil, err
+	}
+
+	m := &MemoryArrayMappedAddress{
+		Handle:         s.Handle,
+		StartAddress:   uint64(s.DWord(0x04)) << 10,
+		EndAddress:     uint64(s.DWord(0x08))<<10 | 0x3ff,
+		ArrayHandle:    s.Word(0x0c),
+		PartitionWidth: s.Byte(0x0e),
+	}
+
+	if s.DWord(0x04) == 0xffffffff && s.DWord(0x08) == 0xffffffff {
+		m.StartAddress = s.QWord(0x0f)
+		m.EndAddress = s.QWord(0x17)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// OnboardDeviceType of an onboard device.
+type OnboardDeviceType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.42.2
+var onboardDeviceTypes = []string{
+	"Other", "Unknown", "Video", "SCSI Controller", "Ethernet", "Token Ring", "Sound", "PATA Controller",
+	"SATA Controller", "SAS Controller", "Wireless LAN", "Bluetooth", "WWAN", "eMMC", "NVMe Controller",
+	"UFS Controller",
+}
+
+func (t OnboardDeviceType) String() string {
+	return enumString(onboardDeviceTypes, uint(t))
+}
+
+// OnboardDevice information (type 41).
+type OnboardDevice struct {
+	Handle       uint16
+	Designation  string // reference designation, like "Embedded NIC 1"
+	Type         OnboardDeviceType
+	Enabled      bool
+	TypeInstance uint8
+	Segment      uint16
+	Bus          uint8
+	Device       uint8
+	Function     uint8
+}
+
+// PCIAddress returns the PCI address of the device, like "0000:19:00.0", or an empty string if not applicable.
+func (d *OnboardDevice) PCIAddress() string {
+	return pciAddress(d.Segment, d.Bus, d.Device, d.Function)
+}
+
+// OnboardDevice decodes onboard devices extended information structure.
+func (s *Structure) OnboardDevice() (*OnboardDevice, error) {
+	if err := s.check(TypeOnboardDevice, 0x0b); err != nil {
+		return nil, err
+	}
+
+	return &OnboardDevice{
+		Handle:       s.Handle,
+		Designation:  s.StringAt(0x04),
+		Type:         OnboardDeviceType(s.Byte(0x05) & 0x7f),
+		Enabled:      s.Byte(0x05)&0x80 != 0,
+		TypeInstance: s.Byte(0x06),
+		Segment:      s.Word(0x07),
+		Bus:          s.Byte(0x09),
+		Device:       s.Byte(0x0a) >> 3,
+		Function:     s.Byte(0x0a) & 0x07,
+	}, nil
+}
+
+// ConnectorType of a port connector.
+type ConnectorType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.9.2
+var connectorTypes = map[ConnectorType]string{
+	0x00: "None", 0x01: "Centronics", 0x02: "Mini Centronics", 0x03: "Proprietary", 0x04: "DB-25 male",
+	0x05: "DB-25 female", 0x06: "DB-15 male", 0x07: "DB-15 female", 0x08: "DB-9 male", 0x09: "DB-9 female",
+	0x0a: "RJ-11", 0x0b: "RJ-45", 0x0c: "50 Pin MiniSCSI", 0x0d: "Mini DIN", 0x0e: "Micro DIN", 0x0f: "PS/2",
+	0x10: "Infrared", 0x11: "HP-HIL", 0x12: "Access Bus (USB)", 0x13: "SSA SCSI", 0x14: "Circular DIN-8 male",
+	0x15: "Circular DIN-8 female", 0x16: "On Board IDE", 0x17: "On Board Floppy", 0x18: "9 Pin Dual Inline (pin 10 cut)",
+	0x19: "25 Pin Dual Inline (pin 26 cut)", 0x1a: "50 Pin Dual Inline", 0x1b: "68 Pin Dual Inline",
+	0x1c: "On Board Sound Input From CD-ROM", 0x1d: "Mini Centronics Type-14", 0x1e: "Mini Centronics Type-26",
+	0x1f: "Mini Jack (headphones)", 0x20: "BNC", 0x21: "IEEE 1394", 0x22: "SAS/SATA Plug Receptacle",
+	0x23: "USB Type-C Receptacle", 0xa0: "PC-98", 0xa1: "PC-98 Hireso", 0xa2: "PC-H98", 0xa3: "PC-98 Note",
+	0xa4: "PC-98 Full", 0xff: "Other",
+}
+
+func (t ConnectorType) String() string {
+	if name, ok := connectorTypes[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%02x", uint8(t))
+}
+
+// PortType of a port connector.
+type PortType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.9.3
+var portTypes = map[PortType]string{
+	0x00: "None", 0x01: "Parallel Port XT/AT Compatible", 0x02: "Parallel Port PS/2", 0x03: "Parallel Port ECP",
+	0x04: "Parallel Port EPP", 0x05: "Parallel Port ECP/EPP", 0x06: "Serial Port XT/AT Compatible",
+	0x07: "Serial Port 16450 Compatible", 0x08: "Serial Port 16550 Compatible", 0x09: "Serial Port 16550A Compatible",
+	0x0a: "SCSI Port", 0x0b: "MIDI Port", 0x0c: "Joy Stick Port", 0x0d: "Keyboard Port", 0x0e: "Mouse Port",
+	0x0f: "SSA SCSI", 0x10: "USB", 0x11: "Firewire (IEEE P1394)", 0x12: "PCMCIA Type I", 0x13: "PCMCIA Type II",
+	0x14: "PCMCIA Type III", 0x15: "Cardbus", 0x16: "Access Bus Port", 0x17: "SCSI II", 0x18: "SCSI Wide",
+	0x19: "PC-98", 0x1a: "PC-98 Hireso", 0x1b: "PC-H98", 0x1c: "Video Port", 0x1d: "Audio Port", 0x1e: "Modem Port",
+	0x1f: "Network Port", 0x20: "SATA", 0x21: "SAS", 0x22: "MFDP (Multi-Function Display Port)", 0x23: "Thunderbolt",
+	0xa0: "8251 Compatible", 0xa1: "8251 FIFO Compatible", 0xff: "Other",
+}
+
+func (t PortType) String() string {
+	if name, ok := portTypes[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%02x", uint8(t))
+}
+
+// PortConnector information (type 8).
+type PortConnector struct {
+	Handle                uint16
+	InternalDesignator    string // reference designator of the connector on the motherboard, like "J1A1"
+	InternalConnectorType ConnectorType
+	ExternalDesignator    string // reference designator of the external connector, like "Rear USB 1"
+	ExternalConnectorType ConnectorType
+	PortType              PortType
+}
+
+// PortConnector decodes port connector information structure.
+func (s *Structure) PortConnector() (*PortConnector, error) {
+	if err := s.check(TypePortConnector, 0x09); err != nil {
+		return nil, err
+	}
+
+	return &PortConnector{
+		Handle:                s.Handle,
+		InternalDesignator:    s.StringAt(0x04),
+		InternalConnectorType: ConnectorType(s.Byte(0x05)),
+		ExternalDesignator:    s.StringAt(0x06),
+		ExternalConnectorType: ConnectorType(s.Byte(0x07)),
+		PortType:              PortType(s.Byte(0x08)),
+	}, nil
+}
@@ -0,0 +1,158 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// BatteryChemistry of a portable battery.
+type BatteryChemistry uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.23.1
+var batteryChemistries = []string{
+	"Other", "Unknown", "Lead Acid", "Nickel Cadmium", "Nickel metal hydride", "Lithium-ion", "Zinc air",
+	"Lithium Polymer",
+}
+
+func (c BatteryChemistry) String() string {
+	return enumString(batteryChemistries, uint(c))
+}
+
+// PortableBattery information (type 22).
+type PortableBattery struct {
+	Handle          uint16
+	Location        string
+	Manufacturer    string
+	ManufactureDate string // either as reported, or the SBDS manufacture date as YYYY-MM-DD
+	SerialNumber    string // either as reported, or the SBDS serial number in hex
+	DeviceName      string
+	Chemistry       BatteryChemistry // 0x02 (Unknown) if SBDSChemistry is set
+	DesignCapacity  uint32           // mWh, 0 if unknown
+	DesignVoltage   uint16           // mV, 0 if unknown
+	SBDSVersion     string           // Smart Battery Data Specification version
+	MaxError        uint8            // percent, 0xff if unknown
+	SBDSChemistry   string
+	OEMSpecific     uint32
+}
+
+// PortableBattery decodes portable battery structure.
+func (s *Structure) PortableBattery() (*PortableBattery, error) {
+	if err := s.check(TypePortableBattery, 0x10); err != nil {
+		return nil, err
+	}
+
+	b := &PortableBattery{
+		Handle:          s.Handle,
+		Location:        s.StringAt(0x04),
+		Manufacturer:    s.StringAt(0x05),
+		ManufactureDate: s.StringAt(0x06),
+		SerialNumber:    s.StringAt(0x07),
+		DeviceName:      s.StringAt(0x08),
+		Chemistry:       BatteryChemistry(s.Byte(0x09)),
+		DesignCapacity:  uint32(s.Word(0x0a)),
+		DesignVoltage:   s.Word(0x0c),
+		SBDSVersion:     s.StringAt(0x0e),
+		MaxError:        s.Byte(0x0f),
+		SBDSChemistry:   s.StringAt(0x14),
+		OEMSpecific:     s.DWord(0x16),
+	}
+
+	// Smart batteries report serial number and manufacture date in the SBDS fields (SMBIOS 2.2+).
+	if b.SerialNumber == "" && s.Word(0x10) != 0 {
+		b.SerialNumber = fmt.Sprintf("%04x", s.Word(0x10))
+	}
+	if date := s.Word(0x12); b.ManufactureDate == "" && date != 0 {
+		b.ManufactureDate = fmt.Sprintf("%d-%02d-%02d", 1980+int(date>>9), date>>5&0x0f, date&0x1f)
+	}
+
+	if m := s.Byte(0x15); m > 0 {
+		b.DesignCapacity *= uint32(m)
+	}
+
+	return b, nil
+}
+
+// PowerSupplyCharacteristics of a system power supply.
+type PowerSupplyCharacteristics uint16
+
+// HotReplaceable reports whether the power supply can be replaced while the system is running.
+func (c PowerSupplyCharacteristics) HotReplaceable() bool {
+	return c&0x0001 != 0
+}
+
+// Present reports whether the power supply is installed.
+func (c PowerSupplyCharacteristics) Present() bool {
+	return c&0x0002 != 0
+}
+
+// Unplugged reports whether the power supply is unplugged from the wall.
+func (c PowerSupplyCharacteristics) Unplugged() bool {
+	return c&0x0004 != 0
+}
+
+// SMBIOS Reference Specification Version 3.8.0, 7.40.1
+var (
+	powerSupplyRangeSwitchings = []string{"Other", "Unknown", "Manual", "Auto-switch", "Wide range", "Not applicable"}
+	powerSupplyStatuses        = []string{"Other", "Unknown", "OK", "Non-critical", "Critical"}
+	powerSupplyTypes           = []string{
+		"Other", "Unknown", "Linear", "Switching", "Battery", "UPS", "Converter", "Regulator",
+	}
+)
+
+// RangeSwitching returns input voltage range switching, like "Auto-switch".
+func (c PowerSupplyCharacteristics) RangeSwitching() string {
+	return enumString(powerSupplyRangeSwitchings, uint(c>>3&0x0f))
+}
+
+// Status returns power supply status, like "OK".
+func (c PowerSupplyCharacteristics) Status() string {
+	return enumString(powerSupplyStatuses, uint(c>>7&0x07))
+}
+
+// Type returns power supply type, like "Switching".
+func (c PowerSupplyCharacteristics) Type() string {
+	return enumString(powerSupplyTypes, uint(c>>10&0x0f))
+}
+
+// PowerSupply information (type 39).
+type PowerSupply struct {
+	Handle           uint16
+	Group            uint8 // power unit group (redundant supplies share one), 0 if not part of a group
+	Location         string
+	DeviceName       string
+	Manufacturer     string
+	SerialNumber     string
+	AssetTag         string
+	ModelPartNumber  string
+	RevisionLevel    string
+	MaxPowerCapacity uint16 // W, 0 if unknown
+	Characteristics  PowerSupplyCharacteristics
+}
+
+// PowerSupply decodes system power supply structure.
+func (s *Structure) PowerSupply() (*PowerSupply, error) {
+	if err := s.check(TypePowerSupply, 0x10); err != nil {
+		return nil, err
+	}
+
+	ps := &PowerSupply{
+		Handle:           s.Handle,
+		Group:            s.Byte(0x04),
+		Location:         s.StringAt(0x05),
+		DeviceName:       s.StringAt(0x06),
+		Manufacturer:     s.StringAt(0x07),
+		SerialNumber:     s.StringAt(0x08),
+		AssetTag:         s.StringAt(0x09),
+		ModelPartNumber:  s.StringAt(0x0a),
+		RevisionLevel:    s.StringAt(0x0b),
+		MaxPowerCapacity: s.Word(0x0c),
+		Characteristics:  PowerSupplyCharacteristics(s.Word(0x0e)),
+	}
+
+	if ps.MaxPowerCapacity == 0x8000 {
+		ps.MaxPowerCapacity = 0
+	}
+
+	return ps, nil
+}
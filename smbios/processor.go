@@ -0,0 +1,236 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// ProcessorType of a processor.
+type ProcessorType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.5.1
+var processorTypes = []string{
+	"Other", "Unknown", "Central Processor", "Math Processor", "DSP Processor", "Video Processor",
+}
+
+func (t ProcessorType) String() string {
+	return enumString(processorTypes, uint(t))
+}
+
+// ProcessorFamily of a processor, merged from the family and family 2 fields.
+type ProcessorFamily uint16
+
+// SMBIOS Reference Specification Version 3.8.0, 7.5.2
+var processorFamilies = map[ProcessorFamily]string{
+	0x01: "Other", 0x02: "Unknown", 0x03: "8086", 0x04: "80286", 0x05: "Intel386", 0x06: "Intel486",
+	0x07: "8087", 0x08: "80287", 0x09: "80387", 0x0a: "80487", 0x0b: "Pentium", 0x0c: "Pentium Pro",
+	0x0d: "Pentium II", 0x0e: "Pentium MMX", 0x0f: "Celeron", 0x10: "Pentium II Xeon", 0x11: "Pentium III",
+	0x12: "M1", 0x13: "M2", 0x14: "Celeron M", 0x15: "Pentium 4 HT", 0x18: "Duron", 0x19: "K5", 0x1a: "K6",
+	0x1b: "K6-2", 0x1c: "K6-3", 0x1d: "Athlon", 0x1e: "AMD29000", 0x1f: "K6-2+",
+	0x20: "Power PC", 0x21: "Power PC 601", 0x22: "Power PC 603", 0x23: "Power PC 603+", 0x24: "Power PC 604",
+	0x25: "Power PC 620", 0x26: "Power PC x704", 0x27: "Power PC 750", 0x28: "Core Duo", 0x29: "Core Duo Mobile",
+	0x2a: "Core Solo Mobile", 0x2b: "Atom", 0x2c: "Core M", 0x2d: "Core m3", 0x2e: "Core m5", 0x2f: "Core m7",
+	0x30: "Alpha", 0x31: "Alpha 21064", 0x32: "Alpha 21066", 0x33: "Alpha 21164", 0x34: "Alpha 21164PC",
+	0x35: "Alpha 21164a", 0x36: "Alpha 21264", 0x37: "Alpha 21364", 0x38: "Turion II Ultra Dual-Core Mobile M",
+	0x39: "Turion II Dual-Core Mobile M", 0x3a: "Athlon II Dual-Core M", 0x3b: "Opteron 6100", 0x3c: "Opteron 4100",
+	0x3d: "Opteron 6200", 0x3e: "Opteron 4200", 0x3f: "FX",
+	0x40: "MIPS", 0x41: "MIPS R4000", 0x42: "MIPS R4200", 0x43: "MIPS R4400", 0x44: "MIPS R4600",
+	0x45: "MIPS R10000", 0x46: "C-Series", 0x47: "E-Series", 0x48: "A-Series", 0x49: "G-Series", 0x4a: "Z-Series",
+	0x4b: "R-Series", 0x4c: "Opteron 4300", 0x4d: "Opteron 6300", 0x4e: "Opteron 3300", 0x4f: "FirePro",
+	0x50: "SPARC", 0x51: "SuperSPARC", 0x52: "MicroSPARC II", 0x53: "MicroSPARC IIep", 0x54: "UltraSPARC",
+	0x55: "UltraSPARC II", 0x56: "UltraSPARC IIi", 0x57: "UltraSPARC III", 0x58: "UltraSPARC IIIi",
+	0x60: "68040", 0x61: "68xxx", 0x62: "68000", 0x63: "68010", 0x64: "68020", 0x65: "68030", 0x66: "Athlon X4",
+	0x67: "Opteron X1000", 0x68: "Opteron X2000", 0x69: "Opteron A-Series", 0x6a: "Opteron X3000", 0x6b: "Zen",
+	0x70: "Hobbit", 0x78: "Crusoe TM5000", 0x79: "Crusoe TM3000", 0x7a: "Efficeon TM8000",
+	0x80: "Weitek", 0x82: "Itanium", 0x83: "Athlon 64", 0x84: "Opteron", 0x85: "Sempron", 0x86: "Turion 64",
+	0x87: "Dual-Core Opteron", 0x88: "Athlon 64 X2", 0x89: "Turion 64 X2", 0x8a: "Quad-Core Opteron",
+	0x8b: "Third-Generation Opteron", 0x8c: "Phenom FX", 0x8d: "Phenom X4", 0x8e: "Phenom X2", 0x8f: "Athlon X2",
+	0x90: "PA-RISC", 0x91: "PA-RISC 8500", 0x92: "PA-RISC 8000", 0x93: "PA-RISC 7300LC", 0x94: "PA-RISC 7200",
+	0x95: "PA-RISC 7100LC", 0x96: "PA-RISC 7100",
+	0xa0: "V30", 0xa1: "Quad-Core Xeon 3200", 0xa2: "Dual-Core Xeon 3000", 0xa3: "Quad-Core Xeon 5300",
+	0xa4: "Dual-Core Xeon 5100", 0xa5: "Dual-Core Xeon 5000", 0xa6: "Dual-Core Xeon LV", 0xa7: "Dual-Core Xeon ULV",
+	0xa8: "Dual-Core Xeon 7100", 0xa9: "Quad-Core Xeon 5400", 0xaa: "Quad-Core Xeon", 0xab: "Dual-Core Xeon 5200",
+	0xac: "Dual-Core Xeon 7200", 0xad: "Quad-Core Xeon 7300", 0xae: "Quad-Core Xeon 7400",
+	0xaf: "Multi-Core Xeon 7400",
+	0xb0: "Pentium III Xeon", 0xb1: "Pentium III Speedstep", 0xb2: "Pentium 4", 0xb3: "Xeon", 0xb4: "AS400",
+	0xb5: "Xeon MP", 0xb6: "Athlon XP", 0xb7: "Athlon MP", 0xb8: "Itanium 2", 0xb9: "Pentium M",
+	0xba: "Celeron D", 0xbb: "Pentium D", 0xbc: "Pentium EE", 0xbd: "Core Solo", 0xbe: "Core 2",
+	0xbf: "Core 2 Duo",
+	0xc0: "Core 2 Solo", 0xc1: "Core 2 Extreme", 0xc2: "Core 2 Quad", 0xc3: "Core 2 Extreme Mobile",
+	0xc4: "Core 2 Duo Mobile", 0xc5: "Core 2 Solo Mobile", 0xc6: "Core i7", 0xc7: "Dual-Core Celeron",
+	0xc8: "IBM390", 0xc9: "G4", 0xca: "G5", 0xcb: "ESA/390 G6", 0xcc: "z/Architecture", 0xcd: "Core i5",
+	0xce: "Core i3", 0xcf: "Core i9",
+	0xd0: "Xeon D", 0xd2: "C7-M", 0xd3: "C7-D", 0xd4: "C7", 0xd5: "Eden", 0xd6: "Multi-Core Xeon",
+	0xd7: "Dual-Core Xeon 3xxx", 0xd8: "Quad-Core Xeon 3xxx", 0xd9: "Nano", 0xda: "Dual-Core Xeon 5xxx",
+	0xdb: "Quad-Core Xeon 5xxx", 0xdd: "Dual-Core Xeon 7xxx", 0xde: "Quad-Core Xeon 7xxx",
+	0xdf: "Multi-Core Xeon 7xxx",
+	0xe0: "Multi-Core Xeon 3400", 0xe4: "Opteron 3000", 0xe5: "Sempron II", 0xe6: "Embedded Opteron Quad-Core",
+	0xe7: "Phenom Triple-Core", 0xe8: "Turion Ultra Dual-Core Mobile", 0xe9: "Turion Dual-Core Mobile",
+	0xea: "Athlon Dual-Core", 0xeb: "Sempron SI", 0xec: "Phenom II", 0xed: "Athlon II", 0xee: "Six-Core Opteron",
+	0xef: "Sempron M",
+	0xfa: "i860", 0xfb: "i960",
+	0x100: "ARMv7", 0x101: "ARMv8", 0x102: "ARMv9", 0x104: "SH-3", 0x105: "SH-4", 0x118: "ARM", 0x119: "StrongARM",
+	0x12c: "6x86", 0x12d: "MediaGX", 0x12e: "MII", 0x140: "WinChip", 0x15e: "DSP", 0x1f4: "Video Processor",
+	0x200: "RV32", 0x201: "RV64", 0x202: "RV128",
+	0x258: "LoongArch", 0x259: "Loongson 1", 0x25a: "Loongson 2", 0x25b: "Loongson 3", 0x25c: "Loongson 2K",
+	0x25d: "Loongson 3A", 0x25e: "Loongson 3B", 0x25f: "Loongson 3C", 0x260: "Loongson 3D", 0x261: "Loongson 3E",
+	0x262: "Dual-Core Loongson 2K 2xxx", 0x26c: "Quad-Core Loongson 3A 5xxx", 0x26d: "Multi-Core Loongson 3A 5xxx",
+	0x26e: "Quad-Core Loongson 3B 5xxx", 0x26f: "Multi-Core Loongson 3B 5xxx", 0x270: "Multi-Core Loongson 3C 5xxx",
+	0x271: "Multi-Core Loongson 3D 5xxx",
+}
+
+func (f ProcessorFamily) String() string {
+	if name, ok := processorFamilies[f]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%02x", uint16(f))
+}
+
+// ProcessorStatus of a processor socket.
+type ProcessorStatus uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.5, offset 18h
+var processorStatuses = []string{
+	"Enabled", "Disabled by user through BIOS setup", "Disabled by BIOS (POST error)", "Idle, waiting to be enabled",
+	"Reserved", "Reserved", "Other",
+}
+
+// Populated reports whether the socket has a processor installed.
+func (st ProcessorStatus) Populated() bool {
+	return st&0x40 != 0
+}
+
+func (st ProcessorStatus) String() string {
+	if st&0x07 == 0 {
+		return "Unknown"
+	}
+
+	return enumString(processorStatuses, uint(st&0x07))
+}
+
+// ProcessorUpgrade is the socket type of a processor.
+type ProcessorUpgrade uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.5.5
+var processorUpgrades = []string{
+	"Other", "Unknown", "Daughter Board", "ZIF Socket", "Replaceable Piggy Back", "None", "LIF Socket", "Slot 1",
+	"Slot 2", "370-pin Socket", "Slot A", "Slot M", "Socket 423", "Socket A (Socket 462)", "Socket 478",
+	"Socket 754", "Socket 940", "Socket 939", "Socket mPGA604", "Socket LGA771", "Socket LGA775", "Socket S1",
+	"Socket AM2", "Socket F (1207)", "Socket LGA1366", "Socket G34", "Socket AM3", "Socket C32", "Socket LGA1156",
+	"Socket LGA1567", "Socket PGA988A", "Socket BGA1288", "Socket rPGA988B", "Socket BGA1023", "Socket BGA1224",
+	"Socket LGA1155", "Socket LGA1356", "Socket LGA2011", "Socket FS1", "Socket FS2", "Socket FM1", "Socket FM2",
+	"Socket LGA2011-3", "Socket LGA1356-3", "Socket LGA1150", "Socket BGA1168", "Socket BGA1234",
+	"Socket BGA1364", "Socket AM4", "Socket LGA1151", "Socket BGA1356", "Socket BGA1440", "Socket BGA1515",
+	"Socket LGA3647-1", "Socket SP3", "Socket SP3r2", "Socket LGA2066", "Socket BGA1392", "Socket BGA1510",
+	"Socket BGA1528", "Socket LGA4189", "Socket LGA1200", "Socket LGA4677", "Socket LGA1700", "Socket BGA1744",
+	"Socket BGA1781", "Socket BGA1211", "Socket BGA2422", "Socket LGA1211", "Socket LGA2422", "Socket LGA5773",
+	"Socket BGA5773", "Socket AM5", "Socket SP5", "Socket SP6",
+}
+
+func (u ProcessorUpgrade) String() string {
+	return enumString(processorUpgrades, uint(u))
+}
+
+// ProcessorCharacteristics bits of a processor.
+type ProcessorCharacteristics uint16
+
+// SMBIOS Reference Specification Version 3.8.0, 7.5.9
+var processorCharacteristics = []string{
+	"", "Unknown", "64-bit capable", "Multi-Core", "Hardware Thread", "Execute Protection",
+	"Enhanced Virtualization", "Power/Performance Control", "128-bit Capable", "Arm64 SoC ID",
+}
+
+// Strings returns names of all the set bits.
+func (c ProcessorCharacteristics) Strings() []string {
+	return bitNames(processorCharacteristics, uint64(c))
+}
+
+// Processor information (type 4).
+type Processor struct {
+	Handle          uint16
+	Socket          string // socket designation
+	Type            ProcessorType
+	Family          ProcessorFamily
+	Manufacturer    string
+	ID              uint64 // for x86, CPUID leaf 1 EAX (signature) in the low and EDX (features) in the high half
+	Version         string
+	Voltage         uint16 // mV, lowest supported voltage for legacy processors, 0 if unknown
+	ExternalClock   uint16 // MHz, 0 if unknown
+	MaxSpeed        uint16 // MHz, 0 if unknown
+	CurrentSpeed    uint16 // MHz at boot time, 0 if unknown
+	Status          ProcessorStatus
+	Upgrade         ProcessorUpgrade
+	SerialNumber    string
+	AssetTag        string
+	PartNumber      string
+	CoreCount       uint16 // 0 if unknown
+	CoreEnabled     uint16 // 0 if unknown
+	ThreadCount     uint16 // 0 if unknown
+	ThreadEnabled   uint16 // 0 if unknown
+	Characteristics ProcessorCharacteristics
+}
+
+// Processor decodes processor information structure.
+func (s *Structure) Processor() (*Processor, error) {
+	if err := s.check(TypeProcessor, 0x1a); err != nil {
+		return nil, err
+	}
+
+	pr := &Processor{
+		Handle:          s.Handle,
+		Socket:          s.StringAt(0x04),
+		Type:            ProcessorType(s.Byte(0x05)),
+		Family:          ProcessorFamily(s.Byte(0x06)),
+		Manufacturer:    s.StringAt(0x07),
+		ID:              s.QWord(0x08),
+		Version:         s.StringAt(0x10),
+		ExternalClock:   s.Word(0x12),
+		MaxSpeed:        s.Word(0x14),
+		CurrentSpeed:    s.Word(0x16),
+		Status:          ProcessorStatus(s.Byte(0x18)),
+		Upgrade:         ProcessorUpgrade(s.Byte(0x19)),
+		SerialNumber:    s.StringAt(0x20),
+		AssetTag:        s.StringAt(0x21),
+		PartNumber:      s.StringAt(0x22),
+		CoreCount:       uint16(s.Byte(0x23)),
+		CoreEnabled:     uint16(s.Byte(0x24)),
+		ThreadCount:     uint16(s.Byte(0x25)),
+		ThreadEnabled:   s.Word(0x30),
+		Characteristics: ProcessorCharacteristics(s.Word(0x26)),
+	}
+
+	// Family 0xfe means the value is in the family 2 field (SMBIOS 2.6+).
+	if pr.Family == 0xfe {
+		pr.Family = ProcessorFamily(s.Word(0x28))
+	}
+
+	// Counts of 255 and more are in the count 2 fields (SMBIOS 3.0+).
+	if pr.CoreCount == 0xff {
+		pr.CoreCount = s.Word(0x2a)
+	}
+	if pr.CoreEnabled == 0xff {
+		pr.CoreEnabled = s.Word(0x2c)
+	}
+	if pr.ThreadCount == 0xff {
+		pr.ThreadCount = s.Word(0x2e)
+	}
+	if pr.ThreadEnabled == 0xffff {
+		pr.ThreadEnabled = 0
+	}
+
+	// Bit 7 set means current voltage in tenths of a volt, otherwise bits 0-2 are supported legacy voltages.
+	switch v := s.Byte(0x11); {
+	case v&0x80 != 0:
+		pr.Voltage = uint16(v&0x7f) * 100
+	case v&0x04 != 0:
+		pr.Voltage = 2900
+	case v&0x02 != 0:
+		pr.Voltage = 3300
+	case v&0x01 != 0:
+		pr.Voltage = 5000
+	}
+
+	return pr, nil
+}
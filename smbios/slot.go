@@ -0,0 +1,149 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// SlotType of a system slot.
+type SlotType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.10.1
+var slotTypes = map[SlotType]string{
+	0x01: "Other", 0x02: "Unknown", 0x03: "ISA", 0x04: "MCA", 0x05: "EISA", 0x06: "PCI", 0x07: "PC Card (PCMCIA)",
+	0x08: "VL-VESA", 0x09: "Proprietary", 0x0a: "Processor Card", 0x0b: "Proprietary Memory Card",
+	0x0c: "I/O Riser Card", 0x0d: "NuBus", 0x0e: "PCI-66", 0x0f: "AGP", 0x10: "AGP 2x", 0x11: "AGP 4x",
+	0x12: "PCI-X", 0x13: "AGP 8x", 0x14: "M.2 Socket 1-DP", 0x15: "M.2 Socket 1-SD", 0x16: "M.2 Socket 2",
+	0x17: "M.2 Socket 3", 0x18: "MXM Type I", 0x19: "MXM Type II", 0x1a: "MXM Type III",
+	0x1b: "MXM Type III-HE", 0x1c: "MXM Type IV", 0x1d: "MXM 3.0 Type A", 0x1e: "MXM 3.0 Type B",
+	0x1f: "PCI Express 2 SFF-8639 (U.2)", 0x20: "PCI Express 3 SFF-8639 (U.2)",
+	0x21: "PCI Express Mini 52-pin with bottom-side keep-outs",
+	0x22: "PCI Express Mini 52-pin without bottom-side keep-outs",
+	0x23: "PCI Express Mini 76-pin", 0x24: "PCI Express 4 SFF-8639 (U.2)", 0x25: "PCI Express 5 SFF-8639 (U.2)",
+	0x26: "OCP NIC 3.0 Small Form Factor (SFF)", 0x27: "OCP NIC 3.0 Large Form Factor (LFF)",
+	0x28: "OCP NIC Prior to 3.0", 0x30: "CXL Flexbus 1.0",
+	0xa0: "PC-98/C20", 0xa1: "PC-98/C24", 0xa2: "PC-98/E", 0xa3: "PC-98/Local Bus", 0xa4: "PC-98/Card",
+	0xa5: "PCI Express", 0xa6: "PCI Express x1", 0xa7: "PCI Express x2", 0xa8: "PCI Express x4",
+	0xa9: "PCI Express x8", 0xaa: "PCI Express x16", 0xab: "PCI Express 2", 0xac: "PCI Express 2 x1",
+	0xad: "PCI Express 2 x2", 0xae: "PCI Express 2 x4", 0xaf: "PCI Express 2 x8", 0xb0: "PCI Express 2 x16",
+	0xb1: "PCI Express 3", 0xb2: "PCI Express 3 x1", 0xb3: "PCI Express 3 x2", 0xb4: "PCI Express 3 x4",
+	0xb5: "PCI Express 3 x8", 0xb6: "PCI Express 3 x16", 0xb8: "PCI Express 4", 0xb9: "PCI Express 4 x1",
+	0xba: "PCI Express 4 x2", 0xbb: "PCI Express 4 x4", 0xbc: "PCI Express 4 x8", 0xbd: "PCI Express 4 x16",
+	0xbe: "PCI Express 5", 0xbf: "PCI Express 5 x1", 0xc0: "PCI Express 5 x2", 0xc1: "PCI Express 5 x4",
+	0xc2: "PCI Express 5 x8", 0xc3: "PCI Express 5 x16", 0xc4: "PCI Express 6+",
+	0xc5: "EDSFF E1", 0xc6: "EDSFF E3",
+}
+
+func (t SlotType) String() string {
+	if name, ok := slotTypes[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%02x", uint8(t))
+}
+
+// SlotWidth is data bus width of a system slot.
+type SlotWidth uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.10.2
+var slotWidths = []string{
+	"Other", "Unknown", "8 bit", "16 bit", "32 bit", "64 bit", "128 bit", "x1", "x2", "x4", "x8", "x12", "x16",
+	"x32",
+}
+
+func (w SlotWidth) String() string {
+	return enumString(slotWidths, uint(w))
+}
+
+// SlotUsage is current usage of a system slot.
+type SlotUsage uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.10.3
+var slotUsages = []string{
+	"Other", "Unknown", "Available", "In use", "Unavailable",
+}
+
+func (u SlotUsage) String() string {
+	return enumString(slotUsages, uint(u))
+}
+
+// SlotLength of a system slot.
+type SlotLength uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.10.4
+var slotLengths = []string{
+	"Other", "Unknown", "Short", "Long", "2.5\" drive form factor", "3.5\" drive form factor",
+}
+
+func (l SlotLength) String() string {
+	return enumString(slotLengths, uint(l))
+}
+
+// SlotCharacteristics bits of a system slot, characteristics 1 in the low and characteristics 2 in the high byte.
+type SlotCharacteristics uint16
+
+// SMBIOS Reference Specification Version 3.8.0, 7.10.6 and 7.10.7
+var slotCharacteristics = []string{
+	"Unknown", "5.0 V", "3.3 V", "Shared opening", "PC Card-16", "CardBus", "Zoom Video", "Modem ring resume",
+	"PME", "Hot-plug", "SMBus", "Bifurcation", "Surprise removal", "CXL 1.0", "CXL 2.0", "CXL 3.0",
+}
+
+// Strings returns names of all the set bits.
+func (c SlotCharacteristics) Strings() []string {
+	return bitNames(slotCharacteristics, uint64(c))
+}
+
+// SystemSlot information (type 9).
+type SystemSlot struct {
+	Handle          uint16
+	Designation     string
+	Type            SlotType
+	Width           SlotWidth
+	Usage           SlotUsage
+	Length          SlotLength
+	ID              uint16
+	Characteristics SlotCharacteristics
+	Segment         uint16 // PCI segment group, 0xffff if not applicable
+	Bus             uint8  // PCI bus, 0xff if not applicable
+	Device          uint8  // PCI device, 0x1f if not applicable
+	Function        uint8  // PCI function, 0x07 if not applicable
+}
+
+// PCIAddress returns the PCI address of the device in (or the bridge to) the slot, like "0000:3b:00.0", or an empty
+// string if not applicable.
+func (s *SystemSlot) PCIAddress() string {
+	return pciAddress(s.Segment, s.Bus, s.Device, s.Function)
+}
+
+// SystemSlot decodes system slot structure.
+func (s *Structure) SystemSlot() (*SystemSlot, error) {
+	if err := s.check(TypeSystemSlot, 0x0c); err != nil {
+		return nil, err
+	}
+
+	slot := &SystemSlot{
+		Handle:          s.Handle,
+		Designation:     s.StringAt(0x04),
+		Type:            SlotType(s.Byte(0x05)),
+		Width:           SlotWidth(s.Byte(0x06)),
+		Usage:           SlotUsage(s.Byte(0x07)),
+		Length:          SlotLength(s.Byte(0x08)),
+		ID:              s.Word(0x09),
+		Characteristics: SlotCharacteristics(uint16(s.Byte(0x0b)) | uint16(s.Byte(0x0c))<<8),
+		Segment:         0xffff,
+		Bus:             0xff,
+		Device:          0x1f,
+		Function:        0x07,
+	}
+
+	// Segment, bus and device/function are available since SMBIOS 2.6.
+	if len(s.Formatted) >= 0x11 {
+		slot.Segment = s.Word(0x0d)
+		slot.Bus = s.Byte(0x0f)
+		slot.Device = s.Byte(0x10) >> 3
+		slot.Function = s.Byte(0x10) & 0x07
+	}
+
+	return slot, nil
+}
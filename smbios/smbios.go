@@ -0,0 +1,323 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+// Package smbios parses SMBIOS (DMI) tables, as specified by DMTF System Management BIOS (SMBIOS) Reference
+// Specification (DSP0134), and decodes the structures found in them.
+//
+// On Linux, the entry point and the table are exported by the kernel in /sys/firmware/dmi/tables, readable by
+// superuser only.
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Locations of the SMBIOS entry point and table exported by Linux kernel.
+const (
+	SysfsEntryPoint = "/sys/firmware/dmi/tables/smbios_entry_point"
+	SysfsTable      = "/sys/firmware/dmi/tables/DMI"
+)
+
+// ErrChecksum is returned when entry point checksum doesn't match.
+var ErrChecksum = errors.New("smbios: entry point checksum mismatch")
+
+// Version of the SMBIOS specification the table conforms to.
+type Version struct {
+	Major    uint8 `json:"major"`
+	Minor    uint8 `json:"minor"`
+	Revision uint8 `json:"revision"` // docrev, SMBIOS 3.0 and later only
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Revision)
+}
+
+// AtLeast reports whether the version is major.minor or later.
+func (v Version) AtLeast(major, minor uint8) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// EntryPoint describes where the table is, and what version of the specification it conforms to.
+type EntryPoint struct {
+	Anchor           string  // "_SM3_" (64-bit, SMBIOS 3.0+), "_SM_" (32-bit, SMBIOS 2.1+) or legacy "_DMI_"
+	Version          Version //
+	Revision         uint8   // entry point revision
+	TableAddress     uint64  // physical address of the table
+	TableLength      uint32  // table length (maximum table length for 64-bit entry point)
+	NumStructures    uint16  // number of structures in the table, 32-bit entry point only
+	MaxStructureSize uint16  // size of the largest structure, 32-bit entry point only
+}
+
+// ParseEntryPoint parses SMBIOS entry point structure, verifying its checksums.
+func ParseEntryPoint(data []byte) (*EntryPoint, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("_SM3_")):
+		if len(data) < 0x18 || len(data) < int(data[0x06]) {
+			return nil, errors.New("smbios: truncated 64-bit entry point")
+		}
+		if checksum(data[:data[0x06]]) != 0 {
+			return nil, ErrChecksum
+		}
+
+		return &EntryPoint{
+			Anchor:       "_SM3_",
+			Version:      Version{data[0x07], data[0x08], data[0x09]},
+			Revision:     data[0x0a],
+			TableLength:  binary.LittleEndian.Uint32(data[0x0c:]),
+			TableAddress: binary.LittleEndian.Uint64(data[0x10:]),
+		}, nil
+
+	case bytes.HasPrefix(data, []byte("_SM_")):
+		if len(data) < 0x1f || len(data) < int(data[0x05]) {
+			return nil, errors.New("smbios: truncated 32-bit entry point")
+		}
+		if checksum(data[:data[0x05]]) != 0 {
+			return nil, ErrChecksum
+		}
+
+		ep, err := parseLegacyEntryPoint(data[0x10:])
+		if err != nil {
+			return nil, err
+		}
+
+		ep.Anchor = "_SM_"
+		ep.Version = Version{Major: data[0x06], Minor: data[0x07]}
+		ep.Revision = data[0x0a]
+		ep.MaxStructureSize = binary.LittleEndian.Uint16(data[0x08:])
+
+		// Some firmware reports 2.33 for 2.3.3, or 2.51 for 2.5.1 (as noted by dmidecode).
+		if ep.Version.Major == 2 && (ep.Version.Minor == 33 || ep.Version.Minor == 51) {
+			ep.Version.Minor /= 10
+		}
+
+		return ep, nil
+
+	case bytes.HasPrefix(data, []byte("_DMI_")):
+		return parseLegacyEntryPoint(data)
+	}
+
+	return nil, errors.New("smbios: unknown entry point anchor")
+}
+
+// Parse legacy DMI entry point, also embedded in 32-bit entry point as intermediate entry point.
+func parseLegacyEntryPoint(data []byte) (*EntryPoint, error) {
+	if len(data) < 0x0f || !bytes.HasPrefix(data, []byte("_DMI_")) {
+		return nil, errors.New("smbios: truncated or missing intermediate entry point")
+	}
+	if checksum(data[:0x0f]) != 0 {
+		return nil, ErrChecksum
+	}
+
+	return &EntryPoint{
+		Anchor:        "_DMI_",
+		Version:       Version{Major: data[0x0e] >> 4, Minor: data[0x0e] & 0x0f},
+		TableLength:   uint32(binary.LittleEndian.Uint16(data[0x06:])),
+		TableAddress:  uint64(binary.LittleEndian.Uint32(data[0x08:])),
+		NumStructures: binary.LittleEndian.Uint16(data[0x0c:]),
+	}, nil
+}
+
+func checksum(data []byte) (sum uint8) {
+	for _, b := range data {
+		sum += b
+	}
+
+	return
+}
+
+// Structure is a single SMBIOS structure: formatted area, followed by strings it references.
+type Structure struct {
+	Type      uint8
+	Handle    uint16
+	Formatted []byte   // formatted area, including the 4 byte header
+	Strings   []string // strings, string number n (as referenced from the formatted area) is Strings[n-1]
+}
+
+// Accessors of the formatted area return zero for fields beyond its length, as fields added in later versions of the
+// specification are missing in structures written for earlier versions.
+
+// Byte returns the byte at offset in the formatted area.
+func (s *Structure) Byte(offset int) uint8 {
+	if offset+1 > len(s.Formatted) {
+		return 0
+	}
+
+	return s.Formatted[offset]
+}
+
+// Word returns the little endian 16-bit value at offset in the formatted area.
+func (s *Structure) Word(offset int) uint16 {
+	if offset+2 > len(s.Formatted) {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint16(s.Formatted[offset:])
+}
+
+// DWord returns the little endian 32-bit value at offset in the formatted area.
+func (s *Structure) DWord(offset int) uint32 {
+	if offset+4 > len(s.Formatted) {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint32(s.Formatted[offset:])
+}
+
+// QWord returns the little endian 64-bit value at offset in the formatted area.
+func (s *Structure) QWord(offset int) uint64 {
+	if offset+8 > len(s.Formatted) {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(s.Formatted[offset:])
+}
+
+// StringAt returns the string referenced by the string number at offset in the formatted area, trimmed of spaces.
+func (s *Structure) StringAt(offset int) string {
+	n := int(s.Byte(offset))
+	if n == 0 || n > len(s.Strings) {
+		return ""
+	}
+
+	return string(bytes.TrimSpace([]byte(s.Strings[n-1])))
+}
+
+// Table of SMBIOS structures.
+type Table struct {
+	EntryPoint *EntryPoint // nil, if the table was parsed without one
+	Structures []*Structure
+}
+
+// Parse SMBIOS table, and its entry point, if not nil. Parsing stops at the end-of-table structure (type 127). On
+// error, the table is still returned, with whatever could be parsed.
+func Parse(entryPoint, table []byte) (*Table, error) {
+	var err error
+	t := &Table{}
+
+	if entryPoint != nil {
+		t.EntryPoint, err = ParseEntryPoint(entryPoint)
+	}
+
+	for offset := 0; offset+4 <= len(table); {
+		length := int(table[offset+1])
+		if length < 4 || offset+length > len(table) {
+			return t, fmt.Errorf("smbios: truncated structure type %d at offset %#x", table[offset], offset)
+		}
+
+		s := &Structure{
+			Type:      table[offset],
+			Handle:    binary.LittleEndian.Uint16(table[offset+2:]),
+			Formatted: table[offset : offset+length],
+		}
+
+		// Strings section ends with double NUL, which is all there is, if there are no strings.
+		end := bytes.Index(table[offset+length:], []byte{0, 0})
+		if end < 0 {
+			return t, fmt.Errorf("smbios: unterminated structure type %d at offset %#x", s.Type, offset)
+		}
+		if end > 0 {
+			for _, str := range bytes.Split(table[offset+length:offset+length+end], []byte{0}) {
+				s.Strings = append(s.Strings, string(str))
+			}
+		}
+
+		t.Structures = append(t.Structures, s)
+		if s.Type == TypeEndOfTable {
+			break
+		}
+
+		offset += length + end + 2
+	}
+
+	return t, err
+}
+
+// Read SMBIOS table of the running system from sysfs. The entry point is optional (it's missing on older kernels).
+func Read() (*Table, error) {
+	table, err := os.ReadFile(SysfsTable)
+	if err != nil {
+		return nil, err
+	}
+
+	entryPoint, err := os.ReadFile(SysfsEntryPoint)
+	if err != nil {
+		entryPoint = nil
+	}
+
+	return Parse(entryPoint, table)
+}
+
+// ParseDump parses SMBIOS dump, either the table alone (like a copy of the sysfs table), or the entry point followed by
+// the table, as written by "dmidecode --dump-bin", with the entry point table address patched to the table offset
+// within the dump (0x20).
+func ParseDump(data []byte) (*Table, error) {
+	if !bytes.HasPrefix(data, []byte("_SM")) && !bytes.HasPrefix(data, []byte("_DMI_")) {
+		return Parse(nil, data)
+	}
+
+	ep, err := ParseEntryPoint(data)
+	if err != nil {
+		return nil, err
+	}
+
+	start := ep.TableAddress
+	if start == 0 || start > uint64(len(data)) {
+		return nil, fmt.Errorf("smbios: table address %#x outside of the dump", ep.TableAddress)
+	}
+
+	// Table length is only the maximum for 64-bit entry point, the table ends with end-of-table structure anyway.
+	end := min(start+uint64(ep.TableLength), uint64(len(data)))
+
+	return Parse(data[:start], data[start:end])
+}
+
+// ReadDump reads and parses SMBIOS dump file (see ParseDump).
+func ReadDump(name string) (*Table, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDump(data)
+}
+
+// Type returns all structures of the given type, in table order.
+func (t *Table) Type(typ uint8) []*Structure {
+	var structures []*Structure
+	for _, s := range t.Structures {
+		if s.Type == typ {
+			structures = append(structures, s)
+		}
+	}
+
+	return structures
+}
+
+// Handle returns the structure with the given handle, or nil.
+func (t *Table) Handle(handle uint16) *Structure {
+	for _, s := range t.Structures {
+		if s.Handle == handle {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// Check that structure is of the expected type, and at least minLength long.
+func (s *Structure) check(typ uint8, minLength int) error {
+	if s.Type != typ {
+		return fmt.Errorf("smbios: structure type %d, want %d", s.Type, typ)
+	}
+	if len(s.Formatted) < minLength {
+		return fmt.Errorf("smbios: structure type %d (handle %#04x) too short: %d bytes, want at least %d", s.Type,
+			s.Handle, len(s.Formatted), minLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,486 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Build a structure from its formatted area (without the 4 byte header) and strings.
+func structure(typ uint8, handle uint16, formatted []byte, strings ...string) []byte {
+	data := []byte{typ, uint8(4 + len(formatted)), 0, 0}
+	binary.LittleEndian.PutUint16(data[2:], handle)
+	data = append(data, formatted...)
+	for _, s := range strings {
+		data = append(data, s...)
+		data = append(data, 0)
+	}
+	if len(strings) == 0 {
+		data = append(data, 0)
+	}
+
+	return append(data, 0)
+}
+
+// Build formatted area of the given length, with values set at offsets (relative to the start of the structure).
+func formatted(length int, values map[int]any) []byte {
+	data := make([]byte, length)
+	for offset, v := range values {
+		switch v := v.(type) {
+		case uint8:
+			data[offset] = v
+		case uint16:
+			binary.LittleEndian.PutUint16(data[offset:], v)
+		case uint32:
+			binary.LittleEndian.PutUint32(data[offset:], v)
+		case uint64:
+			binary.LittleEndian.PutUint64(data[offset:], v)
+		}
+	}
+
+	return data[4:]
+}
+
+// Fix up checksum byte at offset, so that data sums to zero.
+func fixChecksum(data []byte, offset int) {
+	data[offset] = 0
+	data[offset] = -checksum(data)
+}
+
+func entryPoint64(major, minor, docrev uint8, length uint32, address uint64) []byte {
+	ep := make([]byte, 0x18)
+	copy(ep, "_SM3_")
+	ep[0x06] = 0x18
+	ep[0x07], ep[0x08], ep[0x09] = major, minor, docrev
+	ep[0x0a] = 1
+	binary.LittleEndian.PutUint32(ep[0x0c:], length)
+	binary.LittleEndian.PutUint64(ep[0x10:], address)
+	fixChecksum(ep, 0x05)
+
+	return ep
+}
+
+func entryPoint32(major, minor uint8, length uint16, address uint32, num uint16) []byte {
+	ep := make([]byte, 0x1f)
+	copy(ep, "_SM_")
+	ep[0x05] = 0x1f
+	ep[0x06], ep[0x07] = major, minor
+	binary.LittleEndian.PutUint16(ep[0x08:], 0x100)
+	copy(ep[0x10:], "_DMI_")
+	binary.LittleEndian.PutUint16(ep[0x16:], length)
+	binary.LittleEndian.PutUint32(ep[0x18:], address)
+	binary.LittleEndian.PutUint16(ep[0x1c:], num)
+	ep[0x1e] = major<<4 | minor
+	fixChecksum(ep[0x10:], 0x05)
+	fixChecksum(ep, 0x04)
+
+	return ep
+}
+
+func TestParseEntryPoint(t *testing.T) {
+	ep, err := ParseEntryPoint(entryPoint64(3, 3, 0, 0x1234, 0x7b8f1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &EntryPoint{Anchor: "_SM3_", Version: Version{3, 3, 0}, Revision: 1, TableAddress: 0x7b8f1000,
+		TableLength: 0x1234}
+	if !reflect.DeepEqual(ep, want) {
+		t.Errorf("ParseEntryPoint(_SM3_) = %+v, want %+v", ep, want)
+	}
+
+	ep, err = ParseEntryPoint(entryPoint32(2, 8, 0x0a2f, 0x000f0000, 52))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &EntryPoint{Anchor: "_SM_", Version: Version{2, 8, 0}, TableAddress: 0x000f0000, TableLength: 0x0a2f,
+		NumStructures: 52, MaxStructureSize: 0x100}
+	if !reflect.DeepEqual(ep, want) {
+		t.Errorf("ParseEntryPoint(_SM_) = %+v, want %+v", ep, want)
+	}
+	if !ep.Version.AtLeast(2, 7) || ep.Version.AtLeast(3, 0) {
+		t.Errorf("Version %v AtLeast() is wrong", ep.Version)
+	}
+
+	bad := entryPoint64(3, 0, 0, 0, 0)
+	bad[0x07] = 2
+	if _, err := ParseEntryPoint(bad); !errors.Is(err, ErrChecksum) {
+		t.Errorf("ParseEntryPoint() with bad checksum error = %v, want %v", err, ErrChecksum)
+	}
+
+	if _, err := ParseEntryPoint([]byte("_SM3_")); err == nil {
+		t.Error("ParseEntryPoint() of truncated entry point succeeded")
+	}
+}
+
+func TestParse(t *testing.T) {
+	var table []byte
+	table = append(table, structure(TypeBIOS, 0x0000, formatted(0x1a, map[int]any{
+		0x04: uint8(1), 0x05: uint8(2), 0x08: uint8(3), 0x09: uint8(0xff), 0x0a: uint64(0x08), 0x12: uint8(0x03),
+		0x13: uint8(0x0d), 0x14: uint8(5), 0x15: uint8(17), 0x16: uint8(0xff), 0x17: uint8(0xff), 0x18: uint16(32),
+	}), "American Megatrends Inc.", "2.1a", "03/16/2016")...)
+	table = append(table, structure(TypeChassis, 0x0300, formatted(0x1c, map[int]any{
+		0x04: uint8(1), 0x05: uint8(0x97), 0x09: uint8(3), 0x0a: uint8(3), 0x0b: uint8(4), 0x0c: uint8(3),
+		0x11: uint8(2), 0x12: uint8(2), 0x13: uint8(2), 0x14: uint8(3), 0x15: uint8(0x03), 0x16: uint8(1),
+		0x17: uint8(1), 0x18: uint8(0x80 | TypeSystemSlot), 0x19: uint8(0), 0x1a: uint8(6), 0x1b: uint8(2),
+	}), "Huawei", "SKU-1")...)
+	table = append(table, structure(TypeOEMStrings, 0x0b00, []byte{2}, "io.systemd.credential:hostname=web12",
+		" Dell System ", "not counted")...)
+	table = append(table, structure(TypeProcessor, 0x0400, formatted(0x32, map[int]any{
+		0x04: uint8(1), 0x05: uint8(3), 0x06: uint8(0xfe), 0x07: uint8(2), 0x08: uint64(0xbfebfbff000306f2),
+		0x10: uint8(3), 0x11: uint8(0x8c), 0x12: uint16(100), 0x14: uint16(4000), 0x16: uint16(2400),
+		0x18: uint8(0x41), 0x19: uint8(0x2b), 0x23: uint8(0xff), 0x24: uint8(0xff), 0x25: uint8(0xff),
+		0x26: uint16(0x00fc), 0x28: uint16(0xb3), 0x2a: uint16(288), 0x2c: uint16(256), 0x2e: uint16(576),
+	}), "CPU1", "Intel(R) Corporation", "Intel(R) Xeon(R) CPU E5-2630 v3 @ 2.40GHz ")...)
+	table = append(table, structure(TypeCache, 0x0700, formatted(0x1b, map[int]any{
+		0x04: uint8(1), 0x05: uint16(0x0182), 0x07: uint16(0xffff), 0x09: uint16(0xffff), 0x11: uint8(5), 0x12: uint8(0x0e),
+		0x13: uint32(0x80000000 | 640), 0x17: uint32(0x80000000 | 640),
+	}), "L3 Cache")...)
+	table = append(table, structure(TypeSystemSlot, 0x0900, formatted(0x11, map[int]any{
+		0x04: uint8(1), 0x05: uint8(0xb6), 0x06: uint8(0x0d), 0x07: uint8(3), 0x08: uint8(4), 0x09: uint16(2),
+		0x0b: uint8(0x04), 0x0c: uint8(0x03), 0x0f: uint8(0x3a), 0x10: uint8(0x08<<3 | 1),
+	}), "PCIE2")...)
+	table = append(table, structure(TypePhysicalMemoryArray, 0x1000, formatted(0x17, map[int]any{
+		0x04: uint8(0x03), 0x05: uint8(0x03), 0x06: uint8(0x06), 0x07: uint32(0x80000000), 0x0d: uint16(24),
+		0x0f: uint64(3 << 40),
+	}))...)
+	table = append(table, structure(TypeMemoryDevice, 0x1100, formatted(0x5c, map[int]any{
+		0x08: uint16(72), 0x0a: uint16(64), 0x0c: uint16(16384), 0x0e: uint8(0x09), 0x10: uint8(1), 0x11: uint8(2),
+		0x12: uint8(0x1a), 0x13: uint16(0x2080), 0x15: uint16(2133), 0x17: uint8(3), 0x18: uint8(4), 0x1a: uint8(5),
+		0x1b: uint8(2), 0x20: uint16(0xffff), 0x58: uint32(1866), 0x26: uint16(1200),
+	}), "DIMM_A1", "BANK 0", "Samsung", "40A1B2C3", "M393A2G40DB0-CPB")...)
+	table = append(table, structure(TypeMemoryDevice, 0x1101, formatted(0x54, map[int]any{
+		0x0c: uint16(0x7fff), 0x1c: uint32(65536), 0x12: uint8(0x22), 0x15: uint16(0xffff),
+	}))...)
+	table = append(table, structure(TypeMemoryArrayMappedAddress, 0x1300, formatted(0x1f, map[int]any{
+		0x04: uint32(0xffffffff), 0x08: uint32(0xffffffff), 0x0f: uint64(0), 0x17: uint64(1<<36 - 1),
+	}))...)
+	table = append(table, structure(TypeEndOfTable, 0xfeff, nil)...)
+	table = append(table, "garbage after end of table"...)
+
+	tab, err := Parse(nil, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tab.Structures) != 11 {
+		t.Fatalf("Parse() found %d structures, want 11", len(tab.Structures))
+	}
+
+	s := tab.Handle(0x0400)
+	if s == nil || len(s.Strings) != 3 || s.StringAt(0x10) != "Intel(R) Xeon(R) CPU E5-2630 v3 @ 2.40GHz" {
+		t.Fatalf("Handle(0x0400) = %+v, want processor with 3 strings", s)
+	}
+	if s.StringAt(0x21) != "" || s.Word(0x100) != 0 {
+		t.Error("accessors beyond strings or formatted area must return zero values")
+	}
+
+	pr, err := s.Processor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Socket != "CPU1" || pr.CurrentSpeed != 2400 || pr.MaxSpeed != 4000 || pr.ExternalClock != 100 ||
+		pr.Voltage != 1200 || pr.ID != 0xbfebfbff000306f2 || pr.CoreCount != 288 || pr.CoreEnabled != 256 ||
+		pr.ThreadCount != 576 || pr.ThreadEnabled != 0 {
+		t.Errorf("Processor() = %+v", pr)
+	}
+	if pr.Family.String() != "Xeon" || pr.Type.String() != "Central Processor" || !pr.Status.Populated() ||
+		pr.Status.String() != "Enabled" || pr.Upgrade.String() != "Socket LGA2011-3" {
+		t.Errorf("Processor() family %v, type %v, status %v, upgrade %v", pr.Family, pr.Type, pr.Status, pr.Upgrade)
+	}
+	want := []string{"64-bit capable", "Multi-Core", "Hardware Thread", "Execute Protection", "Enhanced Virtualization",
+		"Power/Performance Control"}
+	if !reflect.DeepEqual(pr.Characteristics.Strings(), want) {
+		t.Errorf("Processor() characteristics %v, want %v", pr.Characteristics.Strings(), want)
+	}
+
+	b, err := tab.Type(TypeBIOS)[0].BIOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Vendor != "American Megatrends Inc." || b.ReleaseDate != "03/16/2016" || b.ROMSize != 32<<20 ||
+		b.BIOSMajorRelease != 5 || b.BIOSMinorRelease != 17 || b.FirmwareMajorRelease != 0xff {
+		t.Errorf("BIOS() = %+v", b)
+	}
+	want = []string{"BIOS characteristics not supported", "ACPI", "USB legacy", "BIOS boot specification",
+		"Targeted content distribution", "UEFI"}
+	if got := append(b.Characteristics.Strings(), b.CharacteristicsExt.Strings()...); !reflect.DeepEqual(got, want) {
+		t.Errorf("BIOS() characteristics %v, want %v", got, want)
+	}
+
+	ch, err := tab.Type(TypeChassis)[0].Chassis()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Type.String() != "Rack Mount Chassis" || ch.ThermalState.String() != "Warning" || ch.Height != 2 ||
+		len(ch.Elements) != 2 || ch.Elements[0].String() != "Server Blade" || ch.Elements[1].String() != "SMBIOS type 9" ||
+		ch.Elements[1].Maximum != 6 || ch.SKUNumber != "SKU-1" {
+		t.Errorf("Chassis() = %+v", ch)
+	}
+
+	oem, err := tab.Handle(0x0b00).OEMStrings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"io.systemd.credential:hostname=web12", "Dell System"}; !reflect.DeepEqual(oem, want) {
+		t.Errorf("OEMStrings() = %q, want %q", oem, want)
+	}
+
+	c, err := tab.Handle(0x0700).Cache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Socket != "L3 Cache" || c.Level != 3 || !c.Enabled || c.InstalledSize != 40<<20 || c.Type.String() != "Unified" ||
+		c.Associativity.Ways() != 20 {
+		t.Errorf("Cache() = %+v", c)
+	}
+
+	slot, err := tab.Handle(0x0900).SystemSlot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slot.Designation != "PCIE2" || slot.Type.String() != "PCI Express 3 x16" || slot.Width.String() != "x16" ||
+		slot.Usage.String() != "Available" || slot.PCIAddress() != "0000:3a:08.1" ||
+		!reflect.DeepEqual(slot.Characteristics.Strings(), []string{"3.3 V", "PME", "Hot-plug"}) {
+		t.Errorf("SystemSlot() = %+v", slot)
+	}
+
+	a, err := tab.Handle(0x1000).PhysicalMemoryArray()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Location.String() != "System board or motherboard" || a.ErrorCorrection.String() != "Multi-bit ECC" ||
+		a.MaxCapacity != 3<<40 || a.NumDevices != 24 {
+		t.Errorf("PhysicalMemoryArray() = %+v", a)
+	}
+
+	md, err := tab.Type(TypeMemoryDevice)[0].MemoryDevice()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.DeviceLocator != "DIMM_A1" || md.BankLocator != "BANK 0" || md.Size != 16<<30 || md.Type.String() != "DDR4" ||
+		md.Speed != 2133 || md.ConfiguredSpeed != 1866 || md.SerialNumber != "40A1B2C3" || md.AssetTag != "" ||
+		md.PartNumber != "M393A2G40DB0-CPB" || md.Rank != 2 || md.TotalWidth != 72 || md.ConfiguredVoltage != 1200 {
+		t.Errorf("MemoryDevice() = %+v", md)
+	}
+	if md.FormFactor.String() != "DIMM" || !reflect.DeepEqual(md.TypeDetail.Strings(), []string{"Synchronous",
+		"Registered (Buffered)"}) {
+		t.Errorf("MemoryDevice() form factor %v, type detail %v", md.FormFactor, md.TypeDetail.Strings())
+	}
+
+	md, err = tab.Type(TypeMemoryDevice)[1].MemoryDevice()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.Size != 64<<30 || md.Type.String() != "DDR5" || md.DeviceLocator != "" {
+		t.Errorf("MemoryDevice() with extended size = %+v", md)
+	}
+
+	m, err := tab.Type(TypeMemoryArrayMappedAddress)[0].MemoryArrayMappedAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Size() != 1<<36 {
+		t.Errorf("MemoryArrayMappedAddress().Size() = %d, want %d", m.Size(), uint64(1<<36))
+	}
+
+	if _, err := tab.Structures[0].MemoryDevice(); err == nil {
+		t.Error("MemoryDevice() of processor structure succeeded")
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	table := structure(TypeMemoryDevice, 0x1100, formatted(0x28, nil), "DIMM_A1")
+	table = append(table, structure(TypeMemoryDevice, 0x1101, formatted(0x28, nil), "DIMM_A2")...)
+
+	tab, err := Parse(nil, table[:len(table)-3])
+	if err == nil {
+		t.Error("Parse() of truncated table succeeded")
+	}
+	if len(tab.Structures) != 1 {
+		t.Errorf("Parse() of truncated table returned %d structures, want 1", len(tab.Structures))
+	}
+}
+
+func TestPower(t *testing.T) {
+	var table []byte
+	table = append(table, structure(TypePowerSupply, 0x2700, formatted(0x16, map[int]any{
+		0x04: uint8(1), 0x05: uint8(1), 0x06: uint8(2), 0x07: uint8(3), 0x08: uint8(4), 0x0a: uint8(5),
+		0x0c: uint16(0x8000), 0x0e: uint16(0x11a3),
+	}), "PSU1", "PWR SPLY,750W,RDNT", "DELL", "CN179721AC0412", "0Y9VFC")...)
+	table = append(table, structure(TypePortableBattery, 0x1600, formatted(0x1a, map[int]any{
+		0x04: uint8(1), 0x05: uint8(2), 0x08: uint8(3), 0x09: uint8(2), 0x0a: uint16(5700), 0x0c: uint16(11400),
+		0x10: uint16(0x04d2), 0x12: uint16(43<<9 | 7<<5 | 14), 0x14: uint8(4), 0x15: uint8(10),
+	}), "Front", "LGC", "5B10W13975", "LiP")...)
+
+	tab, err := Parse(nil, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := tab.Structures[0].PowerSupply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := ps.Characteristics
+	if ps.Location != "PSU1" || ps.SerialNumber != "CN179721AC0412" || ps.ModelPartNumber != "0Y9VFC" ||
+		ps.MaxPowerCapacity != 0 || !c.HotReplaceable() || !c.Present() || c.Unplugged() || c.Type() != "Switching" ||
+		c.Status() != "OK" || c.RangeSwitching() != "Auto-switch" {
+		t.Errorf("PowerSupply() = %+v", ps)
+	}
+
+	b, err := tab.Structures[1].PortableBattery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.SerialNumber != "04d2" || b.ManufactureDate != "2023-07-14" || b.DesignCapacity != 57000 ||
+		b.SBDSChemistry != "LiP" || b.DeviceName != "5B10W13975" {
+		t.Errorf("PortableBattery() = %+v", b)
+	}
+}
+
+func TestOnboard(t *testing.T) {
+	var table []byte
+	table = append(table, structure(TypeOnboardDevice, 0x2900, formatted(0x0b, map[int]any{
+		0x04: uint8(1), 0x05: uint8(0x85), 0x06: uint8(1), 0x07: uint16(0), 0x09: uint8(0x19), 0x0a: uint8(0x01),
+	}), "Embedded NIC 1")...)
+	table = append(table, structure(TypePortConnector, 0x0800, formatted(0x09, map[int]any{
+		0x04: uint8(1), 0x05: uint8(0x00), 0x06: uint8(2), 0x07: uint8(0x12), 0x08: uint8(0x10),
+	}), "J1A1", "Rear USB 1")...)
+
+	tab, err := Parse(nil, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := tab.Structures[0].OnboardDevice()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Designation != "Embedded NIC 1" || d.Type.String() != "Ethernet" || !d.Enabled ||
+		d.PCIAddress() != "0000:19:00.1" {
+		t.Errorf("OnboardDevice() = %+v", d)
+	}
+
+	pc, err := tab.Structures[1].PortConnector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pc.InternalDesignator != "J1A1" || pc.InternalConnectorType.String() != "None" ||
+		pc.ExternalConnectorType.String() != "Access Bus (USB)" || pc.PortType.String() != "USB" {
+		t.Errorf("PortConnector() = %+v", pc)
+	}
+}
+
+func TestIPMIDevice(t *testing.T) {
+	table := structure(TypeIPMIDevice, 0x2600, formatted(0x12, map[int]any{
+		0x04: uint8(1), 0x05: uint8(0x20), 0x06: uint8(0x20), 0x07: uint8(0xff), 0x08: uint64(0xca3),
+		0x10: uint8(0x00), 0x11: uint8(0),
+	}))
+	table = append(table, structure(TypeIPMIDevice, 0x2601, formatted(0x12, map[int]any{
+		0x04: uint8(4), 0x05: uint8(0x20), 0x06: uint8(0x20), 0x08: uint64(0x20),
+	}))...)
+
+	tab, err := Parse(nil, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{"0x0000000000000CA2 (I/O)", "0x10 (SMBus)"} {
+		d, err := tab.Structures[i].IPMIDevice()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Address() != want || d.SpecRevision.Major != 2 || d.SpecRevision.Minor != 0 {
+			t.Errorf("IPMIDevice() = %+v, address %s, want %s", d, d.Address(), want)
+		}
+	}
+}
+
+func TestTPMDevice(t *testing.T) {
+	data := formatted(0x1f, map[int]any{
+		0x08: uint8(2), 0x09: uint8(0), 0x0a: uint32(7<<16 | 85), 0x12: uint8(1), 0x13: uint64(0x10),
+	})
+	copy(data[0x04-4:], "IFX\x00")
+
+	tab, err := Parse(nil, structure(TypeTPMDevice, 0x2b00, data, "INFINEON"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := tab.Structures[0].TPMDevice()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.VendorID != "IFX" || d.SpecVersion.Major != 2 || d.FirmwareVersion() != "7.85" || d.Description != "INFINEON" ||
+		!reflect.DeepEqual(d.Characteristics.Strings(), []string{"Family configurable via platform software support"}) {
+		t.Errorf("TPMDevice() = %+v", d)
+	}
+}
+
+func TestFirmwareInventory(t *testing.T) {
+	var table []byte
+	table = append(table, structure(TypeFirmwareInventory, 0x2d00, formatted(0x1c, map[int]any{
+		0x04: uint8(1), 0x05: uint8(2), 0x06: uint8(1), 0x09: uint8(3), 0x0a: uint8(4), 0x0c: ^uint64(0),
+		0x14: uint16(1), 0x16: uint8(4), 0x17: uint8(2), 0x18: uint16(0x2900), 0x1a: uint16(0x2901),
+	}), "BMC Firmware", "1.10", "07/01/2024", "Dell Inc.")...)
+	table = append(table, structure(TypeStringProperty, 0x2e00, formatted(0x09, map[int]any{
+		0x04: uint16(1), 0x06: uint8(1), 0x07: uint16(0x2d00),
+	}), "PciRoot(0x0)/Pci(0x1c,0x0)")...)
+
+	tab, err := Parse(nil, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := tab.Structures[0].FirmwareInventory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.ComponentName != "BMC Firmware" || fi.Version != "1.10" || fi.VersionFormat.String() != "Major.Minor" ||
+		fi.ID != "" || fi.ReleaseDate != "07/01/2024" || fi.Manufacturer != "Dell Inc." || fi.State.String() != "Enabled" ||
+		!reflect.DeepEqual(fi.Characteristics.Strings(), []string{"Updatable"}) ||
+		!reflect.DeepEqual(fi.AssociatedComponents, []uint16{0x2900, 0x2901}) {
+		t.Errorf("FirmwareInventory() = %+v", fi)
+	}
+
+	sp, err := tab.Structures[1].StringProperty()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp.ID.String() != "UEFI device path" || sp.Value != "PciRoot(0x0)/Pci(0x1c,0x0)" || sp.ParentHandle != 0x2d00 {
+		t.Errorf("StringProperty() = %+v", sp)
+	}
+}
+
+func TestParseDump(t *testing.T) {
+	table := append(structure(TypeOEMStrings, 0x0b00, []byte{1}, "dump"), structure(TypeEndOfTable, 0x7f00, nil)...)
+
+	// dmidecode --dump-bin layout, entry point padded to 0x20, followed by the table.
+	dump := append(make([]byte, 0x20), table...)
+	copy(dump, entryPoint64(3, 5, 0, 0x1000, 0x20))
+
+	tab, err := ParseDump(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tab.EntryPoint == nil || tab.EntryPoint.Version.String() != "3.5.0" || len(tab.Structures) != 2 {
+		t.Fatalf("ParseDump() = %+v", tab)
+	}
+	if s, _ := tab.Structures[0].OEMStrings(); !reflect.DeepEqual(s, []string{"dump"}) {
+		t.Errorf("OEMStrings() = %q", s)
+	}
+
+	// Table alone, like a copy of the sysfs table.
+	if tab, err = ParseDump(table); err != nil || tab.EntryPoint != nil || len(tab.Structures) != 2 {
+		t.Errorf("ParseDump(table) = %+v, %v", tab, err)
+	}
+
+	// Entry point copied from sysfs, with the physical address not patched.
+	if _, err = ParseDump(append(entryPoint32(2, 8, uint16(len(table)), 0xf0000, 2), table...)); err == nil {
+		t.Error("ParseDump() with unpatched address succeeded")
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WakeUpType is the event that caused the system to power up.
+type WakeUpType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.2.2
+var wakeUpTypes = []string{
+	"Other", "Unknown", "APM Timer", "Modem Ring", "LAN Remote", "Power Switch", "PCI PME#", "AC Power Restored",
+}
+
+func (w WakeUpType) String() string {
+	return enumString(wakeUpTypes, uint(w))
+}
+
+// System information (type 1).
+type System struct {
+	Handle       uint16
+	Manufacturer string
+	ProductName  string
+	Version      string
+	SerialNumber string
+	WakeUpType   WakeUpType
+	SKUNumber    string
+	Family       string
+}
+
+// System decodes system information structure.
+func (s *Structure) System() (*System, error) {
+	if err := s.check(TypeSystem, 0x08); err != nil {
+		return nil, err
+	}
+
+	return &System{
+		Handle:       s.Handle,
+		Manufacturer: s.StringAt(0x04),
+		ProductName:  s.StringAt(0x05),
+		Version:      s.StringAt(0x06),
+		SerialNumber: s.StringAt(0x07),
+		WakeUpType:   WakeUpType(s.Byte(0x18)),
+		SKUNumber:    s.StringAt(0x19),
+		Family:       s.StringAt(0x1a),
+	}, nil
+}
+
+// Strings referenced by a count at offset 0x04, as in OEM strings and system configuration options structures.
+func (s *Structure) countedStrings(typ uint8) ([]string, error) {
+	if err := s.check(typ, 0x05); err != nil {
+		return nil, err
+	}
+
+	var counted []string
+	for i := 1; i <= int(s.Byte(0x04)) && i <= len(s.Strings); i++ {
+		counted = append(counted, strings.TrimSpace(s.Strings[i-1]))
+	}
+
+	return counted, nil
+}
+
+// OEMStrings decodes OEM strings structure (type 11).
+func (s *Structure) OEMStrings() ([]string, error) {
+	return s.countedStrings(TypeOEMStrings)
+}
+
+// ConfigurationOptions decodes system configuration options structure (type 12).
+func (s *Structure) ConfigurationOptions() ([]string, error) {
+	return s.countedStrings(TypeConfigurationOptions)
+}
+
+// BoardType of a baseboard.
+type BoardType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.3.2
+var boardTypes = []string{
+	"Unknown", "Other", "Server Blade", "Connectivity Switch", "System Management Module", "Processor Module",
+	"I/O Module", "Memory Module", "Daughter board", "Motherboard", "Processor/Memory Module", "Processor/IO Module",
+	"Interconnect board",
+}
+
+func (t BoardType) String() string {
+	return enumString(boardTypes, uint(t))
+}
+
+// ChassisType of a system enclosure.
+type ChassisType uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.4.1
+var chassisTypes = []string{
+	"Other", "Unknown", "Desktop", "Low Profile Desktop", "Pizza Box", "Mini Tower", "Tower", "Portable", "Laptop",
+	"Notebook", "Hand Held", "Docking Station", "All in One", "Sub Notebook", "Space-saving", "Lunch Box",
+	"Main Server Chassis", "Expansion Chassis", "SubChassis", "Bus Expansion Chassis", "Peripheral Chassis",
+	"RAID Chassis", "Rack Mount Chassis", "Sealed-case PC", "Multi-system chassis", "Compact PCI", "Advanced TCA",
+	"Blade", "Blade Enclosure", "Tablet", "Convertible", "Detachable", "IoT Gateway", "Embedded PC", "Mini PC",
+	"Stick PC",
+}
+
+// String returns name of the chassis type, the chassis lock bit is ignored.
+func (t ChassisType) String() string {
+	return enumString(chassisTypes, uint(t&0x7f))
+}
+
+// ChassisState of a system enclosure.
+type ChassisState uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.4.2
+var chassisStates = []string{
+	"Other", "Unknown", "Safe", "Warning", "Critical", "Non-recoverable",
+}
+
+func (st ChassisState) String() string {
+	return enumString(chassisStates, uint(st))
+}
+
+// ChassisSecurityStatus of a system enclosure.
+type ChassisSecurityStatus uint8
+
+// SMBIOS Reference Specification Version 3.8.0, 7.4.3
+var chassisSecurityStatuses = []string{
+	"Other", "Unknown", "None", "External interface locked out", "External interface enabled",
+}
+
+func (st ChassisSecurityStatus) String() string {
+	return enumString(chassisSecurityStatuses, uint(st))
+}
+
+// ChassisElement is a type of element contained in the chassis, either a baseboard type, or an SMBIOS structure type.
+type ChassisElement struct {
+	Type    uint8 // SMBIOS structure type if the top bit is set, baseboard type otherwise
+	Minimum uint8
+	Maximum uint8
+}
+
+func (e ChassisElement) String() string {
+	if e.Type&0x80 != 0 {
+		return fmt.Sprintf("SMBIOS type %d", e.Type&0x7f)
+	}
+
+	return BoardType(e.Type).String()
+}
+
+// Chassis information (type 3).
+type Chassis struct {
+	Handle           uint16
+	Manufacturer     string
+	Type             ChassisType // the top bit is set if the chassis lock is present
+	Version          string
+	SerialNumber     string
+	AssetTag         string
+	BootupState      ChassisState
+	PowerSupplyState ChassisState
+	ThermalState     ChassisState
+	SecurityStatus   ChassisSecurityStatus
+	Height           uint8 // in rack units (U), 0 if unspecified
+	PowerCords       uint8 // 0 if unspecified
+	Elements         []ChassisElement
+	SKUNumber        string
+}
+
+// Chassis decodes system enclosure or chassis structure.
+func (s *Structure) Chassis() (*Chassis, error) {
+	if err := s.check(TypeChassis, 0x09); err != nil {
+		return nil, err
+	}
+
+	c := &Chassis{
+		Handle:           s.Handle,
+		Manufacturer:     s.StringAt(0x04),
+		Type:             ChassisType(s.Byte(0x05)),
+		Version:          s.StringAt(0x06),
+		SerialNumber:     s.StringAt(0x07),
+		AssetTag:         s.StringAt(0x08),
+		BootupState:      ChassisState(s.Byte(0x09)),
+		PowerSupplyState: ChassisState(s.Byte(0x0a)),
+		ThermalState:     ChassisState(s.Byte(0x0b)),
+		SecurityStatus:   ChassisSecurityStatus(s.Byte(0x0c)),
+		Height:           s.Byte(0x11),
+		PowerCords:       s.Byte(0x12),
+	}
+
+	// Contained elements (SMBIOS 2.3+) are records of at least 3 bytes, followed by the SKU number (SMBIOS 2.7+).
+	n, m := int(s.Byte(0x13)), int(s.Byte(0x14))
+	if m < 3 {
+		n = 0
+	}
+	for i := 0; i < n; i++ {
+		offset := 0x15 + i*m
+		if offset+m > len(s.Formatted) {
+			return c, fmt.Errorf("smbios: structure type %d (handle %#04x) contained elements truncated", s.Type,
+				s.Handle)
+		}
+
+		c.Elements = append(c.Elements, ChassisElement{
+			Type:    s.Byte(offset),
+			Minimum: s.Byte(offset + 1),
+			Maximum: s.Byte(offset + 2),
+		})
+	}
+	c.SKUNumber = s.StringAt(0x15 + n*m)
+
+	return c, nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TPMCharacteristics bits of a TPM device.
+type TPMCharacteristics uint64
+
+// SMBIOS Reference Specification Version 3.8.0, 7.44.1
+var tpmCharacteristics = []string{
+	"", "", "TPM device characteristics not supported", "Family configurable via firmware update",
+	"Family configurable via platform software support", "Family configurable via OEM proprietary mechanism",
+}
+
+// Strings returns names of all the set bits.
+func (c TPMCharacteristics) Strings() []string {
+	return bitNames(tpmCharacteristics, uint64(c))
+}
+
+// TPMDevice information (type 43).
+type TPMDevice struct {
+	Handle           uint16
+	VendorID         string // like "IFX" or "NTC"
+	SpecVersion      Version
+	FirmwareVersion1 uint32
+	FirmwareVersion2 uint32
+	Description      string
+	Characteristics  TPMCharacteristics
+	OEMDefined       uint32
+}
+
+// FirmwareVersion returns firmware version in a form depending on the TPM family, like "7.85".
+func (d *TPMDevice) FirmwareVersion() string {
+	switch d.SpecVersion.Major {
+	case 1:
+		// TCPA_VERSION structure, revision major and minor are in the last two bytes.
+		return fmt.Sprintf("%d.%d", uint8(d.FirmwareVersion1>>16), uint8(d.FirmwareVersion1>>24))
+	case 2:
+		return fmt.Sprintf("%d.%d", d.FirmwareVersion1>>16, d.FirmwareVersion1&0xffff)
+	}
+
+	return ""
+}
+
+// TPMDevice decodes TPM device structure.
+func (s *Structure) TPMDevice() (*TPMDevice, error) {
+	if err := s.check(TypeTPMDevice, 0x1b); err != nil {
+		return nil, err
+	}
+
+	return &TPMDevice{
+		Handle:           s.Handle,
+		VendorID:         string(bytes.TrimRight(s.Formatted[0x04:0x08], "\x00 ")),
+		SpecVersion:      Version{Major: s.Byte(0x08), Minor: s.Byte(0x09)},
+		FirmwareVersion1: s.DWord(0x0a),
+		FirmwareVersion2: s.DWord(0x0e),
+		Description:      s.StringAt(0x12),
+		Characteristics:  TPMCharacteristics(s.QWord(0x13)),
+		OEMDefined:       s.DWord(0x1b),
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// Structure types.
+const (
+	TypeBIOS                     = 0
+	TypeSystem                   = 1
+	TypeChassis                  = 3
+	TypeProcessor                = 4
+	TypeCache                    = 7
+	TypePortConnector            = 8
+	TypeSystemSlot               = 9
+	TypeOEMStrings               = 11
+	TypeConfigurationOptions     = 12
+	TypePhysicalMemoryArray      = 16
+	TypeMemoryDevice             = 17
+	TypeMemoryArrayMappedAddress = 19
+	TypePortableBattery          = 22
+	TypeIPMIDevice               = 38
+	TypePowerSupply              = 39
+	TypeOnboardDevice            = 41
+	TypeTPMDevice                = 43
+	TypeFirmwareInventory        = 45
+	TypeStringProperty           = 46
+	TypeEndOfTable               = 127
+)
+
+// Look up name of an enumerated value in a table of names starting at value 1 (values 1 and 2 are "Other" and
+// "Unknown" in most SMBIOS enumerations), fall back to the number itself.
+func enumString(names []string, v uint) string {
+	if v >= 1 && int(v) <= len(names) {
+		return names[v-1]
+	}
+
+	return fmt.Sprintf("0x%02x", v)
+}
+
+// Names of the bits set in v, from a table of names indexed by bit number. Reserved bits have empty names and are
+// skipped.
+func bitNames(names []string, v uint64) []string {
+	var set []string
+	for i, name := range names {
+		if v&(1<<uint(i)) != 0 && name != "" {
+			set = append(set, name)
+		}
+	}
+
+	return set
+}
+
+// Format PCI address, like "0000:3b:00.0", or return an empty string if the address is not applicable (all ones).
+func pciAddress(segment uint16, bus, device, function uint8) string {
+	if segment == 0xffff || bus == 0xff || (device == 0x1f && function == 0x07) {
+		return ""
+	}
+
+	return fmt.Sprintf("%04x:%02x:%02x.%x", segment, bus, device, function)
+}
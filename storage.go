@@ -6,7 +6,9 @@ package sysinfo
 
 import (
 	"bufio"
-	"os"
+	"bytes"
+	"errors"
+	"io/fs"
 	"path"
 	"strconv"
 	"strings"
@@ -20,21 +22,26 @@ type StorageDevice struct {
 	Model  string `json:"model,omitempty"`
 	Serial string `json:"serial,omitempty"`
 	Size   uint   `json:"size,omitempty"` // device size in GB
+
+	// Onboard storage controller designation (see Onboard), empty for add-in controllers.
+	Onboard string `json:"onboard,omitempty"`
 }
 
-func getSerial(name, fullpath string) (serial string) {
-	var f *os.File
+func getSerial(p *probe, name, fullpath string) (serial string) {
+	var data []byte
 	var err error
 
 	// Modern location/format of the udev database.
-	if dev := slurpFile(path.Join(fullpath, "dev")); dev != "" {
-		if f, err = os.Open(path.Join("/run/udev/data", "b"+dev)); err == nil {
+	if dev := p.slurpFile(path.Join(fullpath, "dev")); dev != "" {
+		if data, err = p.readFile(path.Join("/run/udev/data", "b"+dev)); err == nil {
 			goto scan
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			p.failPath(err)
 		}
 	}
 
 	// Legacy location/format of the udev database.
-	if f, err = os.Open(path.Join("/dev/.udev/db", "block:"+name)); err == nil {
+	if data, err = p.readFile(path.Join("/dev/.udev/db", "block:"+name)); err == nil {
 		goto scan
 	}
 
@@ -42,9 +49,7 @@ func getSerial(name, fullpath string) (serial string) {
 	return
 
 scan:
-	defer f.Close()
-
-	s := bufio.NewScanner(f)
+	s := bufio.NewScanner(bytes.NewReader(data))
 	for s.Scan() {
 		if sl := strings.Split(s.Text(), "="); len(sl) == 2 {
 			if sl[0] == "E:ID_SERIAL_SHORT" {
@@ -57,18 +62,20 @@ scan:
 	return
 }
 
-func (si *SysInfo) getStorageInfo() {
+func (si *SysInfo) getStorageInfo(p *probe) {
 	sysBlock := "/sys/block"
-	devices, err := os.ReadDir(sysBlock)
+	devices, err := p.readDir(sysBlock)
 	if err != nil {
+		p.failPath(err)
 		return
 	}
 
 	si.Storage = make([]StorageDevice, 0)
 	for _, link := range devices {
 		fullpath := path.Join(sysBlock, link.Name())
-		dev, err := os.Readlink(fullpath)
+		dev, err := p.readlink(fullpath)
 		if err != nil {
+			p.failPath(err)
 			continue
 		}
 
@@ -78,26 +85,32 @@ func (si *SysInfo) getStorageInfo() {
 
 		// We could filter all removable devices here, but some systems boot from USB flash disks, and then we
 		// would filter them, too. So, let's filter only floppies and CD/DVD devices, and see how it pans out.
-		if strings.HasPrefix(dev, "../devices/platform/floppy") || slurpFile(path.Join(fullpath, "device", "type")) == "5" {
+		if strings.HasPrefix(dev, "../devices/platform/floppy") ||
+			p.slurpOptional(path.Join(fullpath, "device", "type")) == "5" {
 			continue
 		}
 
 		device := StorageDevice{
 			Name:   link.Name(),
-			Model:  slurpFile(path.Join(fullpath, "device", "model")),
-			Serial: getSerial(link.Name(), fullpath),
+			Model:  p.slurpOptional(path.Join(fullpath, "device", "model")),
+			Serial: getSerial(p, link.Name(), fullpath),
 		}
 
-		if driver, err := os.Readlink(path.Join(fullpath, "device", "driver")); err == nil {
+		if driver, err := p.readlink(path.Join(fullpath, "device", "driver")); err == nil {
 			device.Driver = path.Base(driver)
 		}
 
-		if vendor := slurpFile(path.Join(fullpath, "device", "vendor")); !strings.HasPrefix(vendor, "0x") {
+		if vendor := p.slurpOptional(path.Join(fullpath, "device", "vendor")); !strings.HasPrefix(vendor, "0x") {
 			device.Vendor = vendor
 		}
 
-		size, _ := strconv.ParseUint(slurpFile(path.Join(fullpath, "size")), 10, 64)
-		device.Size = uint(size) / 1953125 // GiB
+		if size := p.slurpFile(path.Join(fullpath, "size")); size != "" {
+			if sectors, err := strconv.ParseUint(size, 10, 64); err == nil {
+				device.Size = uint(sectors) / 1953125 // GiB
+			} else {
+				p.failParse(path.Join(fullpath, "size"), err)
+			}
+		}
 
 		si.Storage = append(si.Storage, device)
 	}
@@ -5,45 +5,177 @@
 // Package sysinfo is a Go library providing Linux OS / kernel / hardware system information.
 package sysinfo
 
+import (
+	"context"
+	"maps"
+)
+
 // SysInfo struct encapsulates all other information structs.
 type SysInfo struct {
-	Meta    Meta            `json:"sysinfo"`
-	Node    Node            `json:"node"`
-	OS      OS              `json:"os"`
-	Kernel  Kernel          `json:"kernel"`
-	Product Product         `json:"product"`
-	Board   Board           `json:"board"`
-	Chassis Chassis         `json:"chassis"`
-	BIOS    BIOS            `json:"bios"`
-	CPU     CPU             `json:"cpu"`
-	Memory  Memory          `json:"memory"`
-	Storage []StorageDevice `json:"storage,omitempty"`
-	Network []NetworkDevice `json:"network,omitempty"`
+	// Root, if set, is the directory all files are read from instead of "/", for example a mounted disk image, a
+	// chroot, or the host file system mounted into a container. Symlinks are resolved within Root, absolute links
+	// point to files under Root. Information that is not read from files (CPUID, uname, ethtool) is still gathered
+	// from the running system.
+	Root string `json:"-"`
+
+	// ReadOnly, if set, guarantees that gathering information doesn't modify the system in any way. By default,
+	// missing or mismatched systemd & D-Bus machine IDs are repaired (see Node.MachineIDMismatch). Setting Root
+	// implies ReadOnly, as the files under Root belong to another system.
+	ReadOnly bool `json:"-"`
+
+	// Capture, if set, records everything read from the system while gathering information (see Capture).
+	Capture *Capture `json:"-"`
+
+	// Replay, if set, is a capture that information is gathered from, instead of the running system. Root is ignored.
+	Replay *Capture `json:"-"`
+
+	// SMBIOS, if set, is the absolute path of SMBIOS dump file (see smbios.ParseDump) that SMBIOS information is
+	// decoded from, instead of the table of the running system. Like all other files, it is read from Root, captured,
+	// and replayed. Memory arrays and modules, CPU sockets and speed, slots, onboard devices, BMC and firmware come
+	// from the dump, as do batteries and power supplies, CPU caches and TPM, where the system (sysfs) doesn't know
+	// better, and extras of other sections (product wake-up type and OEM strings, chassis states, BIOS releases and
+	// characteristics). Names, versions and serial numbers of product, board, chassis and BIOS are DMI attributes
+	// exported by the kernel, and are still read from the system.
+	SMBIOS string `json:"-"`
+
+	Meta     Meta                `json:"sysinfo"`
+	Node     Node                `json:"node"`
+	OS       OS                  `json:"os"`
+	Kernel   Kernel              `json:"kernel"`
+	Product  Product             `json:"product"`
+	Board    Board               `json:"board"`
+	Chassis  Chassis             `json:"chassis"`
+	BIOS     BIOS                `json:"bios"`
+	CPU      CPU                 `json:"cpu"`
+	Memory   Memory              `json:"memory"`
+	Storage  []StorageDevice     `json:"storage,omitempty"`
+	Network  []NetworkDevice     `json:"network,omitempty"`
+	Slots    []Slot              `json:"slots,omitempty"`
+	Power    Power               `json:"power"`
+	Onboard  Onboard             `json:"onboard"`
+	BMC      BMC                 `json:"bmc"`
+	TPM      TPM                 `json:"tpm"`
+	Firmware []FirmwareComponent `json:"firmware,omitempty"`
+
+	// Sections gathered by registered collectors (see Register), by collector name.
+	Extensions map[string]any `json:"extensions,omitempty"`
 }
 
 // GetSysInfo gathers all available system information.
 func (si *SysInfo) GetSysInfo() {
+	_ = si.Collect()
+}
+
+// Collect gathers system information, same as GetSysInfo, but also reports what couldn't be gathered. If sections
+// are given, only those sections (and the sections they depend on) are gathered, otherwise all of them.
+//
+// How completely each section was gathered is recorded in Meta.Sections. The returned error, if not nil, is of type
+// Errors, listing which collector failed, on which file or syscall, and why.
+func (si *SysInfo) Collect(sections ...Section) error {
+	return si.GetSysInfoContext(context.Background(), sections...)
+}
+
+// GetSysInfoContext is like Collect, but stops waiting for collectors when the context is done. Sections that weren't
+// gathered in time are left empty, marked unavailable, and reported as failed with the context error.
+func (si *SysInfo) GetSysInfoContext(ctx context.Context, sections ...Section) error {
+	all := tasks(ctx)
+	selected, errs := resolveSections(all, sections)
+
 	// Meta info
 	si.getMetaInfo()
 
-	// DMI info
-	si.getProductInfo()
-	si.getBoardInfo()
-	si.getChassisInfo()
-	si.getBIOSInfo()
+	type result struct {
+		index   int
+		work    *SysInfo
+		errs    Errors
+		dmiRead bool
+		dmiUsed bool
+	}
+
+	// SMBIOS table is read by many collectors, but only once.
+	dmi := &dmiTable{}
+	dmiRead := false
+
+	// Buffered, so that collectors abandoned after the context is done can still finish.
+	results := make(chan result, len(all))
+	started := make([]bool, len(all))
+	finished := make(map[Section]bool)
+	collectorErrs := make([]Errors, len(all))
+	running := 0
+
+loop:
+	for {
+		if ctx.Err() == nil {
+			for i, t := range all {
+				if !selected[t.section] || started[i] || !dependenciesFinished(t.deps, selected, finished) {
+					continue
+				}
+
+				started[i] = true
+				running++
+
+				// Collector works on a private copy, so nothing it does can race with other collectors, or the
+				// caller, if it gets abandoned. Maps are not copied with the struct, so they are cloned, as the
+				// loop keeps recording section status in the original.
+				work := *si
+				work.Meta.Sections = maps.Clone(si.Meta.Sections)
+				work.Extensions = nil
+				go func(i int, work *SysInfo) {
+					p := &probe{si: work, collector: string(all[i].section), dmi: dmi}
+					all[i].collect(work, p)
+					results <- result{i, work, p.errs, p.dmiRead, p.dmiUsed}
+				}(i, &work)
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+
+		select {
+		case r := <-results:
+			running--
+			s := all[r.index].section
+			si.mergeSection(s, r.work)
+			if r.dmiUsed {
+				si.Meta.Sections[s] = si.sectionStatus(s, append(append(Errors{}, r.errs...), dmi.errs...))
+			} else {
+				si.Meta.Sections[s] = si.sectionStatus(s, r.errs)
+			}
+			dmiRead = dmiRead || r.dmiRead
+			finished[s] = true
+			collectorErrs[r.index] = r.errs
+		case <-ctx.Done():
+			break loop
+		}
+	}
 
-	// SMBIOS info
-	si.getMemoryInfo()
+	// Sections that didn't make it in time.
+	if err := ctx.Err(); err != nil {
+		for i, t := range all {
+			if selected[t.section] && !finished[t.section] {
+				si.Meta.Sections[t.section] = StatusUnavailable
+				collectorErrs[i] = append(collectorErrs[i], &CollectorError{
+					Collector: string(t.section),
+					Op:        "collect",
+					Err:       err,
+				})
+			}
+		}
+	}
 
-	// Node info
-	si.getNodeInfo() // depends on BIOS info
+	// Report failures in a stable order, no matter in which order collectors finished. Failures reading SMBIOS table
+	// are reported only once, no matter how many collectors needed it.
+	if dmiRead {
+		errs = append(errs, dmi.errs...)
+	}
+	for _, e := range collectorErrs {
+		errs = append(errs, e...)
+	}
 
-	// Hardware info
-	si.getCPUInfo() // depends on Node info
-	si.getStorageInfo()
-	si.getNetworkInfo()
+	if len(errs) > 0 {
+		return errs
+	}
 
-	// Software info
-	si.getOSInfo()
-	si.getKernelInfo()
+	return nil
 }
@@ -0,0 +1,87 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/zcalusic/sysinfo/smbios"
+)
+
+// TPM (trusted platform module) information, gathered without opening the TPM device.
+type TPM struct {
+	Present         *bool    `json:"present,omitempty"` // nil if it couldn't be determined
+	Version         string   `json:"version,omitempty"` // TPM family, 1.2 or 2.0
+	Device          string   `json:"device,omitempty"`  // like tpm0
+	Description     string   `json:"description,omitempty"`
+	Driver          string   `json:"driver,omitempty"`
+	Vendor          string   `json:"vendor,omitempty"` // TCG vendor ID, like IFX or NTC
+	FirmwareVersion string   `json:"firmwareversion,omitempty"`
+	Characteristics []string `json:"characteristics,omitempty"`
+	ACPITable       bool     `json:"acpitable,omitempty"` // firmware published the ACPI TPM2 table
+}
+
+func (si *SysInfo) getTPMInfo(p *probe) {
+	si.TPM = TPM{}
+	present := false
+
+	sysClassTPM := "/sys/class/tpm"
+	devices, err := p.readDir(sysClassTPM)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		p.failPath(err)
+	}
+	if len(devices) > 0 {
+		fullpath := path.Join(sysClassTPM, devices[0].Name())
+
+		present = true
+		si.TPM.Device = devices[0].Name()
+		si.TPM.Description = p.slurpOptional(path.Join(fullpath, "device", "description"))
+		if driver, err := p.readlink(path.Join(fullpath, "device", "driver")); err == nil {
+			si.TPM.Driver = path.Base(driver)
+		}
+
+		switch p.slurpOptional(path.Join(fullpath, "tpm_version_major")) {
+		case "1":
+			si.TPM.Version = "1.2"
+		case "2":
+			si.TPM.Version = "2.0"
+		}
+	}
+
+	// The table itself is readable only by root, but its presence is visible to everybody.
+	if _, err := p.stat("/sys/firmware/acpi/tables/TPM2"); err == nil {
+		present = true
+		si.TPM.ACPITable = true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		p.failPath(err)
+	}
+
+	if t := getSMBIOS(p); t != nil {
+		for _, s := range t.Type(smbios.TypeTPMDevice) {
+			d, err := s.TPMDevice()
+			if err != nil {
+				p.failSMBIOS(err)
+				continue
+			}
+
+			present = true
+			si.TPM.Version = fmt.Sprintf("%d.%d", d.SpecVersion.Major, d.SpecVersion.Minor)
+			si.TPM.Vendor = d.VendorID
+			si.TPM.FirmwareVersion = d.FirmwareVersion()
+			si.TPM.Characteristics = d.Characteristics.Strings()
+			if si.TPM.Description == "" {
+				si.TPM.Description = d.Description
+			}
+			break
+		}
+	}
+
+	if present || p.conclusive() {
+		si.TPM.Present = &present
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright © 2016 Zlatko Čalušić
+//
+// Use of this source code is governed by an MIT-style license that can be found in the LICENSE file.
+
+package sysinfo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zcalusic/sysinfo"
+)
+
+func TestTPM(t *testing.T) {
+	// Infineon TPM 2.0, firmware 7.85.
+	tpm := structure(43, 0x2b00, formatted(0x1f, map[int]any{
+		0x04: uint32('I' | 'F'<<8 | 'X'<<16), 0x08: uint8(2), 0x0a: uint32(7<<16 | 85), 0x12: uint8(1),
+		0x13: uint64(1 << 3),
+	}), "INFINEON")
+
+	present, absent := true, false
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  sysinfo.TPM
+	}{
+		{
+			name: "sysfs",
+			files: map[string]string{
+				"sys/class/tpm/tpm0/tpm_version_major":  "2",
+				"sys/class/tpm/tpm0/device/description": "TPM 2.0 Device",
+			},
+			want: sysinfo.TPM{Present: &present, Version: "2.0", Device: "tpm0", Description: "TPM 2.0 Device"},
+		},
+		{
+			name:  "smbios",
+			files: map[string]string{"sys/firmware/dmi/tables/DMI": tpm + endOfTable},
+			want: sysinfo.TPM{
+				Present:         &present,
+				Version:         "2.0",
+				Description:     "INFINEON",
+				Vendor:          "IFX",
+				FirmwareVersion: "7.85",
+				Characteristics: []string{"Family configurable via firmware update"},
+			},
+		},
+		{
+			name:  "acpi",
+			files: map[string]string{"sys/firmware/acpi/tables/TPM2": ""},
+			want:  sysinfo.TPM{Present: &present, ACPITable: true},
+		},
+		{
+			name:  "none",
+			files: map[string]string{"sys/firmware/acpi/tables/DSDT": ""},
+			want:  sysinfo.TPM{Present: &absent},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			si := sysinfo.SysInfo{Root: fixture(t, tt.files)}
+			_ = si.Collect(sysinfo.SectionTPM)
+
+			if !reflect.DeepEqual(si.TPM, tt.want) {
+				t.Errorf("TPM = %+v, want %+v", si.TPM, tt.want)
+			}
+		})
+	}
+}
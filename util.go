@@ -5,22 +5,260 @@
 package sysinfo
 
 import (
+	"errors"
+	"io/fs"
 	"os"
+	"path"
+	"runtime"
+	"strconv"
 	"strings"
 )
 
-// Read one-liner text files, strip newline.
-func slurpFile(path string) string {
-	data, err := os.ReadFile(path)
+// probe gathers information on behalf of a single collector, keeping track of its failures.
+type probe struct {
+	si        *SysInfo
+	collector string
+	errs      Errors
+	dmi       *dmiTable // SMBIOS table shared by all collectors of the run
+	dmiRead   bool      // the collector read the SMBIOS table
+	dmiUsed   bool      // and its failures count toward the collector's section
+}
+
+// Translate absolute path to the configured root file system. Symlinks are resolved within the root, so that absolute
+// links (like /lib64/ld-linux-x86-64.so.2 -> /lib/x86_64-linux-gnu/ld-linux-x86-64.so.2) don't lead to the host.
+func (si *SysInfo) path(name string) string {
+	if si.Root == "" {
+		return name
+	}
+
+	return path.Join(si.Root, si.resolve(name))
+}
+
+// Same as path, but the last element of the path is not resolved, for functions that don't follow it (lstat,
+// readlink).
+func (si *SysInfo) lpath(name string) string {
+	if si.Root == "" {
+		return name
+	}
+
+	dir, file := path.Split(path.Clean("/" + name))
+	return path.Join(si.Root, si.resolve(dir), file)
+}
+
+// Resolve symlinks in absolute path within the root, the same way the kernel does it within a chroot.
+func (si *SysInfo) resolve(name string) string {
+	// Limit on the number of links followed, like MAXSYMLINKS in the kernel.
+	const maxLinks = 40
+
+	resolved := "/"
+	parts := strings.Split(name, "/")
+	for links := 0; len(parts) > 0; {
+		part := parts[0]
+		parts = parts[1:]
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			resolved = path.Dir(resolved)
+			continue
+		}
+
+		next := path.Join(resolved, part)
+		link, err := os.Readlink(path.Join(si.Root, next))
+		if err != nil || links >= maxLinks {
+			resolved = next
+			continue
+		}
+		links++
+
+		if path.IsAbs(link) {
+			resolved = "/"
+		}
+		parts = append(strings.Split(link, "/"), parts...)
+	}
+
+	return resolved
+}
+
+// Thin wrappers around os file system functions, resolving paths against the configured root, recording everything
+// read into the capture, or reading it from the replayed capture instead. Failures are left to the caller to report,
+// as only the caller knows whether the file is expected to exist, and carry the path as given, not the path under
+// the root.
+
+// Replace the path under the root in a failed operation with the path as given.
+func unroot(err error, name string) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		pe.Path = name
+	}
+
+	return err
+}
+
+func (p *probe) readFile(name string) ([]byte, error) {
+	if p.si.Replay != nil {
+		return p.si.Replay.readFile(name)
+	}
+
+	data, err := os.ReadFile(p.si.path(name))
+	if err == nil {
+		p.si.Capture.add(name, 0444, data, "")
+	}
+
+	return data, unroot(err, name)
+}
+
+func (p *probe) readDir(name string) ([]fs.DirEntry, error) {
+	if p.si.Replay != nil {
+		return p.si.Replay.readDir(name)
+	}
+
+	entries, err := os.ReadDir(p.si.path(name))
+	if err == nil && p.si.Capture != nil {
+		p.si.Capture.add(name, fs.ModeDir|0555, nil, "")
+		for _, e := range entries {
+			entry := path.Join(name, e.Name())
+			if e.Type()&fs.ModeSymlink != 0 {
+				if link, err := os.Readlink(p.si.lpath(entry)); err == nil {
+					p.si.Capture.add(entry, 0, nil, link)
+				}
+			} else {
+				p.si.Capture.add(entry, e.Type()|0444, nil, "")
+			}
+		}
+	}
+
+	return entries, unroot(err, name)
+}
+
+func (p *probe) readlink(name string) (string, error) {
+	if p.si.Replay != nil {
+		return p.si.Replay.readlink(name)
+	}
+
+	link, err := os.Readlink(p.si.lpath(name))
+	if err == nil {
+		p.si.Capture.add(name, 0, nil, link)
+	}
+
+	return link, unroot(err, name)
+}
+
+func (p *probe) stat(name string) (fs.FileInfo, error) {
+	if p.si.Replay != nil {
+		return p.si.Replay.stat("stat", name)
+	}
+
+	fi, err := os.Stat(p.si.path(name))
+	if err == nil {
+		p.si.Capture.add(name, fi.Mode(), nil, "")
+	}
+
+	return fi, unroot(err, name)
+}
+
+func (p *probe) lstat(name string) (fs.FileInfo, error) {
+	if p.si.Replay != nil {
+		return p.si.Replay.stat("lstat", name)
+	}
+
+	fi, err := os.Lstat(p.si.lpath(name))
+	if err == nil && p.si.Capture != nil {
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			if link, err := os.Readlink(p.si.lpath(name)); err == nil {
+				p.si.Capture.add(name, 0, nil, link)
+			}
+		} else {
+			p.si.Capture.add(name, fi.Mode(), nil, "")
+		}
+	}
+
+	return fi, unroot(err, name)
+}
+
+// Results of syscalls are captured as files in this directory.
+const syscallDir = "/.sysinfo"
+
+// Call live function, or look up its result in the replayed capture.
+func (p *probe) syscall(name string, live func() ([]byte, error)) ([]byte, error) {
+	if p.si.Replay != nil {
+		return p.si.Replay.readFile(path.Join(syscallDir, name))
+	}
+
+	data, err := live()
+	if err == nil {
+		p.si.Capture.add(path.Join(syscallDir, name), 0444, data, "")
+	}
+
+	return data, err
+}
+
+// Number of logical CPUs usable by the current process.
+func (p *probe) numCPU() uint {
+	data, _ := p.syscall("numcpu", func() ([]byte, error) {
+		return []byte(strconv.Itoa(runtime.NumCPU())), nil
+	})
+
+	n, _ := strconv.ParseUint(string(data), 10, 64)
+	return uint(n)
+}
+
+// Trim spaces & \u0000 \uffff
+func trimSpace(data []byte) string {
+	return strings.Trim(string(data), " \r\n\t\u0000\uffff")
+}
+
+// Read one-liner text files, strip newline. Failures are reported.
+func (p *probe) slurpFile(path string) string {
+	data, err := p.readFile(path)
 	if err != nil {
+		p.failPath(err)
 		return ""
 	}
 
-	// Trim spaces & \u0000 \uffff
-	return strings.Trim(string(data), " \r\n\t\u0000\uffff")
+	return trimSpace(data)
 }
 
-// Write one-liner text files, add newline, ignore errors (best effort).
-func spewFile(path string, data string, perm os.FileMode) {
-	_ = os.WriteFile(path, []byte(data+"\n"), perm)
+// Read optional one-liner text files, a missing file is not reported as a failure.
+func (p *probe) slurpOptional(path string) string {
+	data, err := p.readFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			p.failPath(err)
+		}
+		return ""
+	}
+
+	return trimSpace(data)
+}
+
+// Read optional numeric one-liner files, 0 if missing or malformed (reported as a parse failure).
+func (p *probe) slurpUint(path string) uint {
+	data := p.slurpOptional(path)
+	if data == "" {
+		return 0
+	}
+
+	n, err := strconv.ParseUint(data, 10, 64)
+	if err != nil {
+		p.failParse(path, err)
+	}
+
+	return uint(n)
+}
+
+// Write one-liner text files, add newline, failures are reported but otherwise ignored (best effort). Nothing is
+// written when replaying a capture, or when reading another system under Root.
+func (p *probe) spewFile(path string, data string, perm os.FileMode) bool {
+	if p.si.Replay != nil || p.si.Root != "" {
+		return false
+	}
+
+	if err := os.WriteFile(p.si.path(path), []byte(data+"\n"), perm); err != nil {
+		p.failPath(err)
+		return false
+	}
+
+	return true
 }